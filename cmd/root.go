@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/shuv1824/recommender/internal/handler"
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/services/travel"
 	"github.com/shuv1824/recommender/internal/services/weather"
 	"github.com/shuv1824/recommender/internal/utils/geodata"
@@ -28,11 +30,77 @@ func Run() error {
 	}
 
 	districts := geodata.Districts()
-	slog.Info("Loaded districts", "count", len(districts))
+	slog.Info("Loaded districts", "count", len(districts), "divisions", len(geodata.Divisions()))
 
-	weatherService := weather.NewCachedWeatherService(districts, 5*time.Minute)
-	travelService := travel.NewTravelService(districts)
-	recommendationHandler := handler.NewRecommendationHandler(weatherService, travelService)
+	// Upazilas only refine destinations, so the service runs without them
+	if err := geodata.LoadUpazilas("data/upazilas.json"); err != nil {
+		slog.Warn("failed to load upazilas", "error", err)
+	}
+	upazilas := geodata.Upazilas()
+	slog.Info("Loaded upazilas", "count", len(upazilas))
+
+	// Without boundaries origins are located by the nearest district center,
+	// and the country outline decides whether they are in Bangladesh
+	if err := geodata.LoadBoundaries("data/districts.geojson"); errors.Is(err, fs.ErrNotExist) {
+		slog.Info("No district boundaries; locating origins by the nearest district center")
+	} else if err != nil {
+		slog.Warn("failed to load district boundaries", "error", err)
+	}
+	boundaries := geodata.DistrictBoundaries()
+	if boundaries != nil {
+		slog.Info("Loaded district boundaries", "count", boundaries.Len())
+	}
+	if err := geodata.LoadCountry("data/bangladesh.geojson"); err != nil {
+		slog.Warn("failed to load the outline of Bangladesh", "error", err)
+	}
+	country := geodata.CountryBoundary()
+
+	base, err := forecastProvider()
+	if err != nil {
+		return fmt.Errorf("failed to configure forecast provider: %w", err)
+	}
+
+	// Share fetched series per coordinate between requests
+	seriesTTL := 5 * time.Minute
+	provider := forecast.NewCachedProvider(base, seriesTTL)
+
+	weatherService := weather.NewCachedWeatherService(provider, districts, 5*time.Minute)
+	travelService := travel.NewTravelService(provider, districts)
+	travelService.SetUpazilas(upazilas)
+	travelService.SetBoundaries(boundaries)
+	travelService.SetCountry(country)
+
+	scoring, err := travel.ParseScoringConfig(
+		os.Getenv("SCORING_WEIGHTS"),
+		os.Getenv("SCORING_SCALES"),
+		os.Getenv("SCORING_THRESHOLDS"),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid scoring config: %w", err)
+	}
+	if err := travelService.SetScoringConfig(scoring); err != nil {
+		return fmt.Errorf("invalid scoring config: %w", err)
+	}
+
+	horizon, err := travel.ParseHorizonConfig(os.Getenv("FORECAST_DAYS"), os.Getenv("AIR_QUALITY_DAYS"))
+	if err != nil {
+		return fmt.Errorf("invalid horizon config: %w", err)
+	}
+	if err := travelService.SetHorizonConfig(horizon); err != nil {
+		return fmt.Errorf("invalid horizon config: %w", err)
+	}
+	slog.Info("Forecast horizons", "forecast_days", horizon.ForecastDays, "air_quality_days", horizon.AirQualityDays)
+
+	// The district ranking reads the same cached series as the travel service
+	// when the forecast window covers its days
+	if horizon.ForecastDays >= weather.RankingDays {
+		weatherService.SetWindow(travelService.ForecastWindow)
+	}
+	index := geodata.NewIndex(districts, geodata.Divisions(), upazilas)
+	index.SetBoundaries(boundaries)
+	index.SetCountry(country)
+	recommendationHandler := handler.NewRecommendationHandler(weatherService, travelService, index)
+	districtHandler := handler.NewDistrictHandler(index, weatherService)
 
 	// Warm cache on startup (fetch data before serving requests)
 	slog.Info("Warming weather cache...")
@@ -44,8 +112,16 @@ func Run() error {
 	}
 	cancel()
 
+	// Warm per-district forecast series for the best-destination finder
+	ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+	if err := travelService.WarmCache(ctx); err != nil {
+		slog.Error("Warning: failed to warm forecast series cache: ", "error", err)
+	}
+	cancel()
+
 	// Start background cache refresh
 	weatherService.StartBackgroundRefresh(context.Background())
+	travelService.StartBackgroundRefresh(context.Background(), seriesTTL/2) // Refresh before expiry
 
 	// Initialize router
 	r := mux.NewRouter()
@@ -59,6 +135,18 @@ func Run() error {
 	// Weather/Destination routes
 	api.HandleFunc("/destinations/top", recommendationHandler.GetTopDestinations).Methods(http.MethodGet)
 	api.HandleFunc("/travel/recommendation", recommendationHandler.GetRecommendation).Methods(http.MethodPost)
+	api.HandleFunc("/travel/best-day", recommendationHandler.GetBestDay).Methods(http.MethodPost)
+	api.HandleFunc("/travel/best-destinations", recommendationHandler.GetBestDestinations).Methods(http.MethodPost)
+	api.HandleFunc("/travel/compare", recommendationHandler.CompareDestinations).Methods(http.MethodPost)
+	api.HandleFunc("/travel/itinerary", recommendationHandler.GetItinerary).Methods(http.MethodPost)
+	api.HandleFunc("/travel/group", recommendationHandler.GetGroupTrip).Methods(http.MethodPost)
+
+	// District routes
+	api.HandleFunc("/districts", districtHandler.ListDistricts).Methods(http.MethodGet)
+	api.HandleFunc("/districts/search", districtHandler.Search).Methods(http.MethodGet)
+	api.HandleFunc("/districts/{id}", districtHandler.GetDistrict).Methods(http.MethodGet)
+	api.HandleFunc("/divisions/summary", recommendationHandler.GetDivisionSummary).Methods(http.MethodGet)
+	api.HandleFunc("/geocode/reverse", districtHandler.ReverseGeocode).Methods(http.MethodGet)
 
 	var h http.Handler = r
 
@@ -84,6 +172,29 @@ func Run() error {
 	return startServer(server)
 }
 
+// forecastProvider uses Open-Meteo's default model unless FORECAST_MODELS lists
+// models to blend, e.g. "ecmwf_ifs025,gfs_seamless,icon_seamless".
+// FORECAST_BLEND_METHOD is median (default) or weighted, and
+// FORECAST_MODEL_WEIGHTS gives model=weight pairs for weighted blends.
+func forecastProvider() (forecast.Provider, error) {
+	openMeteo := forecast.NewOpenMeteo(nil)
+
+	config, err := forecast.ParseBlendConfig(
+		os.Getenv("FORECAST_MODELS"),
+		os.Getenv("FORECAST_BLEND_METHOD"),
+		os.Getenv("FORECAST_MODEL_WEIGHTS"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return openMeteo, nil
+	}
+
+	slog.Info("Blending forecast models", "models", config.Models, "method", config.Method)
+	return forecast.NewBlendedProvider(openMeteo, *config)
+}
+
 func setupLogger() *slog.Logger {
 	var handler slog.Handler
 	isDevelopment := true
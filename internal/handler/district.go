@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shuv1824/recommender/internal/response"
+	"github.com/shuv1824/recommender/internal/services/weather"
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geodata"
+)
+
+const (
+	// defaultSearchLimit is how many matches a district search returns by default
+	defaultSearchLimit = 5
+	// defaultPerPage and maxPerPage bound a page of the district catalogue
+	defaultPerPage = 20
+	maxPerPage     = 64
+)
+
+type DistrictHandler struct {
+	districts      *geodata.Index
+	weatherService *weather.CachedWeatherService
+}
+
+func NewDistrictHandler(districts *geodata.Index, weatherService *weather.CachedWeatherService) *DistrictHandler {
+	return &DistrictHandler{
+		districts:      districts,
+		weatherService: weatherService,
+	}
+}
+
+// ListDistricts returns a page of the district catalogue. Optional query
+// parameters: division (ID or name), page (default 1) and per_page
+// (default 20, at most 64).
+func (h *DistrictHandler) ListDistricts(w http.ResponseWriter, r *http.Request) {
+	page, err := positiveIntParam(r, "page", 1)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	perPage, err := positiveIntParam(r, "per_page", defaultPerPage)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if perPage > maxPerPage {
+		response.ErrorJSON(w, http.StatusBadRequest, fmt.Sprintf("per_page must be at most %d", maxPerPage))
+		return
+	}
+
+	start := time.Now()
+
+	districts := h.districts.Districts()
+	if d := r.URL.Query().Get("division"); d != "" {
+		division, ok := h.districts.Division(d)
+		if !ok {
+			response.ErrorJSON(w, http.StatusBadRequest, "division not found: "+d)
+			return
+		}
+		districts = h.districts.InDivision(division.ID)
+	}
+
+	resp := types.DistrictListResponse{
+		Districts:  []types.District{},
+		Page:       page,
+		PerPage:    perPage,
+		Total:      len(districts),
+		TotalPages: (len(districts) + perPage - 1) / perPage,
+	}
+
+	// Pages past the end are empty rather than an error
+	if from := (page - 1) * perPage; from < len(districts) {
+		resp.Districts = districts[from:min(from+perPage, len(districts))]
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// GetDistrict returns a district's details, its upazilas and its latest
+// cached weather
+func (h *DistrictHandler) GetDistrict(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	start := time.Now()
+
+	district, ok := h.districts.ByID(id)
+	if !ok {
+		response.ErrorJSON(w, http.StatusNotFound, "district not found: "+id)
+		return
+	}
+
+	details := types.DistrictDetails{
+		District: district,
+		Upazilas: h.districts.UpazilasIn(district.ID),
+	}
+
+	// Weather is only included once the cache has been filled
+	if dw, updatedAt, ok := h.weatherService.CachedDistrict(district.ID); ok {
+		dw.Debug = nil
+		details.Weather = &dw
+		details.WeatherUpdatedAt = updatedAt.Format(time.RFC3339)
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, details)
+}
+
+// Search finds districts by English or Bangla name, historic spelling or a
+// misspelling. Query parameters: q (required) and limit (default 5).
+func (h *DistrictHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		response.ErrorJSON(w, http.StatusBadRequest, "q is required")
+		return
+	}
+
+	limit, err := positiveIntParam(r, "limit", defaultSearchLimit)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+
+	resp := types.DistrictSearchResponse{
+		Query:   query,
+		Matches: []types.DistrictMatch{},
+	}
+	exact := false
+	for _, m := range h.districts.Search(query, limit) {
+		resp.Matches = append(resp.Matches, types.DistrictMatch{
+			District:  m.District,
+			Matched:   m.Matched,
+			MatchType: m.MatchType,
+			Distance:  m.Distance,
+		})
+		if m.MatchType != geodata.MatchPrefix && m.MatchType != geodata.MatchFuzzy {
+			exact = true
+		}
+	}
+
+	// Only typos and partial names matched
+	if !exact {
+		for _, m := range resp.Matches {
+			resp.DidYouMean = append(resp.DidYouMean, m.Name)
+		}
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// ReverseGeocode names the district, and upazila where known, that a point
+// is in. Query parameters: lat and long (both required). Points outside
+// Bangladesh get the nearest district and a warning.
+func (h *DistrictHandler) ReverseGeocode(w http.ResponseWriter, r *http.Request) {
+	lat, err := floatParam(r, "lat", 90)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	long, err := floatParam(r, "long", 180)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+
+	result := h.districts.ReverseGeocode(lat, long)
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// floatParam reads a required number query parameter within ±limit
+func floatParam(r *http.Request, name string, limit float64) (float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || f < -limit || f > limit {
+		return 0, fmt.Errorf("%s must be a number within ±%g", name, limit)
+	}
+	return f, nil
+}
+
+// positiveIntParam reads an optional positive integer query parameter
+func positiveIntParam(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
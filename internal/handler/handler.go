@@ -3,24 +3,30 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/shuv1824/recommender/internal/response"
 	"github.com/shuv1824/recommender/internal/services/travel"
 	"github.com/shuv1824/recommender/internal/services/weather"
 	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geodata"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
 )
 
 type RecommendationHandler struct {
 	weatherService *weather.CachedWeatherService
 	travelService  *travel.TravelService
+	districts      *geodata.Index
 }
 
-func NewRecommendationHandler(weatherService *weather.CachedWeatherService, travelService *travel.TravelService) *RecommendationHandler {
+func NewRecommendationHandler(weatherService *weather.CachedWeatherService, travelService *travel.TravelService, districts *geodata.Index) *RecommendationHandler {
 	return &RecommendationHandler{
 		weatherService: weatherService,
 		travelService:  travelService,
+		districts:      districts,
 	}
 }
 
@@ -31,14 +37,38 @@ func Health(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetTopDestinations returns top 10 coolest and cleanest districts
+// GetTopDestinations returns top 10 coolest and cleanest districts.
+// Optional query parameters: rank_by (temperature|air_quality),
+// pollutants (comma-separated, e.g. pm25,o3,no2), division (ID or name) and
+// debug=true to include per-model forecast values.
 func (h *RecommendationHandler) GetTopDestinations(w http.ResponseWriter, r *http.Request) {
+	opts, err := rankOptions(r)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	area := "Bangladesh"
+	var division types.Division
+	if d := r.URL.Query().Get("division"); d != "" {
+		var ok bool
+		division, ok = h.districts.Division(d)
+		if !ok {
+			response.ErrorJSON(w, http.StatusBadRequest, "division not found: "+d)
+			return
+		}
+		opts.DivisionID = division.ID
+		area = division.Name + " division"
+	}
+
+	debug := r.URL.Query().Get("debug") == "true"
+
 	ctx, cancel := context.WithTimeout(r.Context(), 490*time.Millisecond)
 	defer cancel()
 
 	start := time.Now()
 
-	destinations, err := h.weatherService.GetTopCoolestAndCleanest(ctx)
+	destinations, err := h.weatherService.GetTopDestinations(ctx, opts)
 	if err != nil {
 		// If context deadline exceeded, return cached or error
 		if ctx.Err() == context.DeadlineExceeded {
@@ -49,9 +79,24 @@ func (h *RecommendationHandler) GetTopDestinations(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if !debug {
+		for i := range destinations {
+			destinations[i].Debug = nil
+		}
+	}
+
+	pollutantLabels := strings.Join(pollutant.Labels(opts.Pollutants), ", ")
+	description := "Top 10 coolest and cleanest districts in " + area + " based on 7-day forecast (2PM temperature and " + pollutantLabels + " levels)"
+	if opts.RankBy == weather.RankByAirQuality {
+		description = "Top 10 cleanest and coolest districts in " + area + " based on 7-day forecast (2PM " + pollutantLabels + " levels and temperature)"
+	}
+
 	resp := types.TopDestinationsResponse{
 		GeneratedAt:  time.Now().Format(time.RFC3339),
-		Description:  "Top 10 coolest and cleanest districts in Bangladesh based on 7-day forecast (2PM temperature and PM2.5 levels)",
+		Description:  description,
+		Division:     division.Name,
+		RankBy:       opts.RankBy,
+		Pollutants:   opts.Pollutants,
 		Destinations: destinations,
 	}
 
@@ -61,6 +106,64 @@ func (h *RecommendationHandler) GetTopDestinations(w http.ResponseWriter, r *htt
 	response.JSON(w, http.StatusOK, resp)
 }
 
+// GetDivisionSummary returns each division's average temperature and PM2.5
+// and its best district. Optional query parameters: rank_by and pollutants,
+// as for GetTopDestinations, choose the best district.
+func (h *RecommendationHandler) GetDivisionSummary(w http.ResponseWriter, r *http.Request) {
+	opts, err := rankOptions(r)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 490*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	summaries, err := h.weatherService.GetDivisionSummaries(ctx, h.districts.Divisions(), opts)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			response.ErrorJSON(w, http.StatusGatewayTimeout, "request timeout - try again")
+			return
+		}
+		response.ErrorJSON(w, http.StatusInternalServerError, "failed to fetch weather data")
+		return
+	}
+
+	resp := types.DivisionSummaryResponse{
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		RankBy:      opts.RankBy,
+		Pollutants:  opts.Pollutants,
+		Divisions:   summaries,
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// rankOptions reads the rank_by and pollutants query parameters
+func rankOptions(r *http.Request) (weather.RankOptions, error) {
+	opts := weather.DefaultRankOptions
+
+	if rankBy := r.URL.Query().Get("rank_by"); rankBy != "" {
+		if rankBy != weather.RankByTemperature && rankBy != weather.RankByAirQuality {
+			return opts, fmt.Errorf("rank_by must be one of: temperature, air_quality")
+		}
+		opts.RankBy = rankBy
+	}
+
+	pollutants, err := pollutant.Parse(r.URL.Query().Get("pollutants"))
+	if err != nil {
+		return opts, err
+	}
+	opts.Pollutants = pollutants
+
+	return opts, nil
+}
+
 func (h *RecommendationHandler) GetRecommendation(w http.ResponseWriter, r *http.Request) {
 	var body types.TravelRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -73,11 +176,16 @@ func (h *RecommendationHandler) GetRecommendation(w http.ResponseWriter, r *http
 		response.ErrorJSON(w, http.StatusBadRequest, "current_location lat and long are required")
 		return
 	}
-	if body.DestinationDistrictName == "" {
-		response.ErrorJSON(w, http.StatusBadRequest, "destination_district_id is required")
+	if body.DestinationDistrictName == "" && body.DestinationDistrictID == "" && body.DestinationUpazila == "" && body.DestinationLocation == nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "destination_district, destination_district_id, destination_upazila or destination_location is required")
 		return
 	}
-	if body.TravelDate == "" {
+	isTrip := body.StartDate != "" || body.EndDate != ""
+	if isTrip && (body.StartDate == "" || body.EndDate == "") {
+		response.ErrorJSON(w, http.StatusBadRequest, "start_date and end_date are both required for a multi-day trip")
+		return
+	}
+	if !isTrip && body.TravelDate == "" {
 		response.ErrorJSON(w, http.StatusBadRequest, "travel_date is required (format: YYYY-MM-DD)")
 		return
 	}
@@ -90,11 +198,33 @@ func (h *RecommendationHandler) GetRecommendation(w http.ResponseWriter, r *http
 			Name: body.CurrentLocation.Name,
 		},
 		DestinationDistrictName: body.DestinationDistrictName,
+		DestinationDistrictID:   body.DestinationDistrictID,
+		DestinationUpazila:      body.DestinationUpazila,
+		DestinationLocation:     body.DestinationLocation,
 		TravelDate:              body.TravelDate,
+		StartDate:               body.StartDate,
+		EndDate:                 body.EndDate,
+		Pollutants:              body.Pollutants,
+		TravelerProfile:         body.TravelerProfile,
+		Ensemble:                body.Ensemble,
+		Debug:                   body.Debug,
+		Route:                   body.Route,
 	}
 
 	start := time.Now()
 
+	if isTrip {
+		trip, err := h.travelService.GetTripRecommendation(r.Context(), req)
+		if err != nil {
+			response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		w.Header().Set("X-Response-Time", time.Since(start).String())
+		response.JSON(w, http.StatusOK, trip)
+		return
+	}
+
 	recommendation, err := h.travelService.GetRecommendation(r.Context(), req)
 	if err != nil {
 		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
@@ -106,3 +236,169 @@ func (h *RecommendationHandler) GetRecommendation(w http.ResponseWriter, r *http
 
 	response.JSON(w, http.StatusOK, recommendation)
 }
+
+// GetBestDay scores every forecast day for a trip and returns the best one
+func (h *RecommendationHandler) GetBestDay(w http.ResponseWriter, r *http.Request) {
+	var req types.BestDayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields
+	if req.CurrentLocation.Lat == 0 && req.CurrentLocation.Long == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "current_location lat and long are required")
+		return
+	}
+	if req.DestinationDistrictName == "" && req.DestinationDistrictID == "" && req.DestinationUpazila == "" && req.DestinationLocation == nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "destination_district, destination_district_id, destination_upazila or destination_location is required")
+		return
+	}
+
+	start := time.Now()
+
+	bestDay, err := h.travelService.GetBestDay(r.Context(), req)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, bestDay)
+}
+
+// GetBestDestinations ranks every district against the current location on a date
+func (h *RecommendationHandler) GetBestDestinations(w http.ResponseWriter, r *http.Request) {
+	var req types.BestDestinationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields
+	if req.CurrentLocation.Lat == 0 && req.CurrentLocation.Long == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "current_location lat and long are required")
+		return
+	}
+	if req.TravelDate == "" {
+		response.ErrorJSON(w, http.StatusBadRequest, "travel_date is required (format: YYYY-MM-DD)")
+		return
+	}
+
+	start := time.Now()
+
+	destinations, err := h.travelService.GetBestDestinations(r.Context(), req)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, destinations)
+}
+
+// CompareDestinations ranks a shortlist of districts against the current location
+func (h *RecommendationHandler) CompareDestinations(w http.ResponseWriter, r *http.Request) {
+	var req types.CompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields
+	if req.CurrentLocation.Lat == 0 && req.CurrentLocation.Long == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "current_location lat and long are required")
+		return
+	}
+	if len(req.DestinationDistrictNames) == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "destination_districts is required")
+		return
+	}
+	if req.TravelDate == "" {
+		response.ErrorJSON(w, http.StatusBadRequest, "travel_date is required (format: YYYY-MM-DD)")
+		return
+	}
+
+	start := time.Now()
+
+	ranking, err := h.travelService.CompareDestinations(r.Context(), req)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, ranking)
+}
+
+// GetItinerary evaluates an ordered multi-stop trip and suggests a better order
+func (h *RecommendationHandler) GetItinerary(w http.ResponseWriter, r *http.Request) {
+	var req types.ItineraryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields
+	if req.CurrentLocation.Lat == 0 && req.CurrentLocation.Long == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "current_location lat and long are required")
+		return
+	}
+	if len(req.Stops) == 0 {
+		response.ErrorJSON(w, http.StatusBadRequest, "stops is required")
+		return
+	}
+
+	start := time.Now()
+
+	itinerary, err := h.travelService.GetItinerary(r.Context(), req)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, itinerary)
+}
+
+// GetGroupTrip ranks candidate destinations for travelers from several origins
+func (h *RecommendationHandler) GetGroupTrip(w http.ResponseWriter, r *http.Request) {
+	var req types.GroupTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields
+	for i, origin := range req.Origins {
+		if origin.Lat == 0 && origin.Long == 0 {
+			response.ErrorJSON(w, http.StatusBadRequest, fmt.Sprintf("origins[%d] lat and long are required", i))
+			return
+		}
+	}
+	if req.TravelDate == "" {
+		response.ErrorJSON(w, http.StatusBadRequest, "travel_date is required (format: YYYY-MM-DD)")
+		return
+	}
+
+	start := time.Now()
+
+	trip, err := h.travelService.GetGroupTrip(r.Context(), req)
+	if err != nil {
+		response.ErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Add response time header
+	w.Header().Set("X-Response-Time", time.Since(start).String())
+
+	response.JSON(w, http.StatusOK, trip)
+}
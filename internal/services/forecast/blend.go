@@ -0,0 +1,203 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Blend methods
+const (
+	BlendMedian   = "median"
+	BlendWeighted = "weighted"
+)
+
+// BlendConfig selects the forecast models to combine and how
+type BlendConfig struct {
+	Models []string
+	Method string // BlendMedian or BlendWeighted
+	// Weights per model for BlendWeighted; models without a weight count as 1
+	Weights map[string]float64
+}
+
+// Validate checks the blend configuration
+func (c BlendConfig) Validate() error {
+	if len(c.Models) == 0 {
+		return fmt.Errorf("at least one forecast model is required")
+	}
+	if c.Method != BlendMedian && c.Method != BlendWeighted {
+		return fmt.Errorf("blend method must be %q or %q", BlendMedian, BlendWeighted)
+	}
+	for model, w := range c.Weights {
+		if !slices.Contains(c.Models, model) {
+			return fmt.Errorf("weight for model %q, which is not blended; the models are %s", model, strings.Join(c.Models, ", "))
+		}
+		if w < 0 {
+			return fmt.Errorf("weight for model %q must not be negative", model)
+		}
+	}
+	return nil
+}
+
+// ParseBlendConfig reads a blend configuration from its text form: a
+// comma-separated model list, a method (median when empty) and optional
+// comma-separated model=weight pairs, e.g. "ecmwf_ifs025=2,gfs_seamless=1".
+// An empty model list means no blending and returns nil.
+func ParseBlendConfig(models, method, weights string) (*BlendConfig, error) {
+	config := BlendConfig{Method: strings.TrimSpace(method)}
+	for _, m := range strings.Split(models, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			config.Models = append(config.Models, m)
+		}
+	}
+	if len(config.Models) == 0 {
+		return nil, nil
+	}
+	if config.Method == "" {
+		config.Method = BlendMedian
+	}
+
+	for _, pair := range strings.Split(weights, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		model, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("model weight %q must be model=weight", pair)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("model weight %q: %w", pair, err)
+		}
+		if config.Weights == nil {
+			config.Weights = make(map[string]float64)
+		}
+		config.Weights[strings.TrimSpace(model)] = w
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// MultiModelProvider is a Provider that can also fetch several forecast
+// models' series for a coordinate at once
+type MultiModelProvider interface {
+	Provider
+	// ForecastModels returns each model's hourly series and their shared timestamps
+	ForecastModels(ctx context.Context, lat, long float64, q Query, models []string) (map[string]*Hourly, []string, error)
+}
+
+// BlendedProvider queries several forecast models for the same coordinate and
+// blends them hour by hour. Air quality, ensembles and history come from the base provider.
+type BlendedProvider struct {
+	base   MultiModelProvider
+	config BlendConfig
+}
+
+// NewBlendedProvider wraps a multi-model provider with blending
+func NewBlendedProvider(base MultiModelProvider, config BlendConfig) (*BlendedProvider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &BlendedProvider{base: base, config: config}, nil
+}
+
+// Forecast fetches every configured model and blends temperature and UV index.
+// The per-model series are kept on the result for debugging.
+func (b *BlendedProvider) Forecast(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	perModel, times, err := b.base.ForecastModels(ctx, lat, long, q, b.config.Models)
+	if err != nil {
+		return nil, err
+	}
+
+	blended := &Hourly{
+		Time:        times,
+		Temperature: make([]float64, len(times)),
+		UVIndex:     make([]float64, len(times)),
+		Models:      perModel,
+		BlendMethod: b.config.Method,
+	}
+
+	for i := range times {
+		blended.Temperature[i] = b.blendAt(perModel, i, func(h *Hourly) []float64 { return h.Temperature })
+		blended.UVIndex[i] = b.blendAt(perModel, i, func(h *Hourly) []float64 { return h.UVIndex })
+	}
+
+	return blended, nil
+}
+
+// AirQuality delegates to the base provider; air quality has a single model
+func (b *BlendedProvider) AirQuality(ctx context.Context, lat, long float64, q Query) (*HourlyAirQuality, error) {
+	return b.base.AirQuality(ctx, lat, long, q)
+}
+
+// TemperatureEnsemble delegates to the base provider
+func (b *BlendedProvider) TemperatureEnsemble(ctx context.Context, lat, long float64, q Query) (*Ensemble, error) {
+	return b.base.TemperatureEnsemble(ctx, lat, long, q)
+}
+
+// Historical delegates to the base provider; the archive is a single reanalysis
+func (b *BlendedProvider) Historical(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	return b.base.Historical(ctx, lat, long, q)
+}
+
+// blendAt combines the models' values at hour i, skipping missing values.
+// Returns NaN when no model has a value.
+func (b *BlendedProvider) blendAt(perModel map[string]*Hourly, i int, series func(*Hourly) []float64) float64 {
+	var values, weights []float64
+	for _, model := range b.config.Models {
+		h, ok := perModel[model]
+		if !ok {
+			continue
+		}
+		s := series(h)
+		if i >= len(s) || math.IsNaN(s[i]) {
+			continue
+		}
+
+		w, ok := b.config.Weights[model]
+		if !ok {
+			w = 1
+		}
+		values = append(values, s[i])
+		weights = append(weights, w)
+	}
+
+	if len(values) == 0 {
+		return nan
+	}
+
+	if b.config.Method == BlendMedian {
+		return median(values)
+	}
+
+	var sum, totalWeight float64
+	for j, v := range values {
+		sum += v * weights[j]
+		totalWeight += weights[j]
+	}
+	if totalWeight == 0 {
+		return median(values)
+	}
+	return sum / totalWeight
+}
+
+// median returns the median of values
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
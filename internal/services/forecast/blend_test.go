@@ -0,0 +1,188 @@
+package forecast
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBlendAt(t *testing.T) {
+	perModel := map[string]*Hourly{
+		"a": {Temperature: []float64{30, 20}},
+		"b": {Temperature: []float64{32, nan}},
+		"c": {Temperature: []float64{37, 24}},
+	}
+	temperature := func(h *Hourly) []float64 { return h.Temperature }
+
+	tests := []struct {
+		name     string
+		config   BlendConfig
+		hour     int
+		expected float64
+	}{
+		{
+			name:     "median of three models",
+			config:   BlendConfig{Models: []string{"a", "b", "c"}, Method: BlendMedian},
+			hour:     0,
+			expected: 32,
+		},
+		{
+			name:     "median skips missing values",
+			config:   BlendConfig{Models: []string{"a", "b", "c"}, Method: BlendMedian},
+			hour:     1,
+			expected: 22,
+		},
+		{
+			name:     "weighted mean",
+			config:   BlendConfig{Models: []string{"a", "b", "c"}, Method: BlendWeighted, Weights: map[string]float64{"a": 2, "c": 0}},
+			hour:     0,
+			expected: (30*2 + 32) / 3.0,
+		},
+		{
+			name:     "unknown model is ignored",
+			config:   BlendConfig{Models: []string{"a", "missing"}, Method: BlendMedian},
+			hour:     0,
+			expected: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BlendedProvider{config: tt.config}
+			got := b.blendAt(perModel, tt.hour, temperature)
+			if math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("expected %.4f, got %.4f", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("no values returns NaN", func(t *testing.T) {
+		b := &BlendedProvider{config: BlendConfig{Models: []string{"b"}, Method: BlendMedian}}
+		if got := b.blendAt(perModel, 1, temperature); !math.IsNaN(got) {
+			t.Errorf("expected NaN, got %.4f", got)
+		}
+	})
+}
+
+func TestBlendConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  BlendConfig
+		wantErr bool
+	}{
+		{"valid median", BlendConfig{Models: []string{"a"}, Method: BlendMedian}, false},
+		{"no models", BlendConfig{Method: BlendMedian}, true},
+		{"unknown method", BlendConfig{Models: []string{"a"}, Method: "mean"}, true},
+		{"negative weight", BlendConfig{Models: []string{"a"}, Method: BlendWeighted, Weights: map[string]float64{"a": -1}}, true},
+		{"weight for a model not blended", BlendConfig{Models: []string{"a", "b"}, Method: BlendWeighted, Weights: map[string]float64{"c": 2}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseBlendConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		models   string
+		method   string
+		weights  string
+		expected *BlendConfig
+		wantErr  bool
+	}{
+		{name: "no models means no blending", expected: nil},
+		{
+			name:     "median by default",
+			models:   "ecmwf_ifs025, gfs_seamless,,icon_seamless",
+			expected: &BlendConfig{Models: []string{"ecmwf_ifs025", "gfs_seamless", "icon_seamless"}, Method: BlendMedian},
+		},
+		{
+			name:     "weighted with weights",
+			models:   "a,b",
+			method:   "weighted",
+			weights:  "a=2, b=0.5",
+			expected: &BlendConfig{Models: []string{"a", "b"}, Method: BlendWeighted, Weights: map[string]float64{"a": 2, "b": 0.5}},
+		},
+		{name: "unknown method", models: "a", method: "mean", wantErr: true},
+		{name: "weight without model", models: "a", method: "weighted", weights: "2", wantErr: true},
+		{name: "weight not a number", models: "a", method: "weighted", weights: "a=heavy", wantErr: true},
+		{name: "misspelled weight key", models: "ecmwf_ifs025,gfs_seamless", method: "weighted", weights: "ecmwf_ifs25=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseBlendConfig(tt.models, tt.method, tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+// stubModels serves fixed per-model series
+type stubModels struct {
+	Provider
+	perModel map[string]*Hourly
+}
+
+func (s stubModels) ForecastModels(ctx context.Context, lat, long float64, q Query, models []string) (map[string]*Hourly, []string, error) {
+	return s.perModel, []string{"2025-12-25T14:00"}, nil
+}
+
+func TestBlendedProviderForecast(t *testing.T) {
+	base := stubModels{perModel: map[string]*Hourly{
+		"a": {Temperature: []float64{30}, UVIndex: []float64{7}},
+		"b": {Temperature: []float64{34}, UVIndex: []float64{9}},
+	}}
+	b, err := NewBlendedProvider(base, BlendConfig{Models: []string{"a", "b"}, Method: BlendMedian})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h, err := b.Forecast(context.Background(), 23.8, 90.4, Query{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Temperature[0] != 32 || h.UVIndex[0] != 8 || len(h.Models) != 2 || h.BlendMethod != BlendMedian {
+		t.Errorf("expected the median of both models, got %+v", h)
+	}
+
+	t.Run("one model", func(t *testing.T) {
+		// Open-Meteo only suffixes variables with the model name for several models
+		client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"hourly":{"time":["2025-12-25T14:00"],"temperature_2m":[29.5],"uv_index":[6.5]}}`
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		})}
+		b, err := NewBlendedProvider(NewOpenMeteo(client), BlendConfig{Models: []string{"ecmwf_ifs025"}, Method: BlendMedian})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		h, err := b.Forecast(context.Background(), 23.8, 90.4, Query{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if h.Temperature[0] != 29.5 || h.UVIndex[0] != 6.5 || h.Models["ecmwf_ifs025"] == nil {
+			t.Errorf("expected the single model's series, got %+v", h)
+		}
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
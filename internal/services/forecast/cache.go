@@ -0,0 +1,150 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// fetchTimeout bounds a shared fetch, which outlives the caller that started it
+const fetchTimeout = 30 * time.Second
+
+// CachedProvider wraps a Provider and caches each series per coordinate and
+// date range, so repeated lookups for the same place reuse one fetch.
+// Concurrent lookups of a missing series share a single in-flight fetch.
+// Cached series are shared between callers and must not be modified.
+type CachedProvider struct {
+	base     Provider
+	ttl      time.Duration
+	mu       sync.RWMutex
+	entries  map[string]cacheEntry
+	inFlight map[string]*fetchCall
+}
+
+type cacheEntry struct {
+	value     any
+	fetchedAt time.Time
+}
+
+// fetchCall is a fetch other callers can wait on
+type fetchCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// NewCachedProvider creates a caching provider
+func NewCachedProvider(base Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		base:     base,
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+		inFlight: make(map[string]*fetchCall),
+	}
+}
+
+// Forecast returns the cached forecast series or fetches it
+func (c *CachedProvider) Forecast(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	v, err := c.get(ctx, cacheKey("forecast", lat, long, q), func(ctx context.Context) (any, error) {
+		return c.base.Forecast(ctx, lat, long, q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Hourly), nil
+}
+
+// AirQuality returns the cached air-quality series or fetches it
+func (c *CachedProvider) AirQuality(ctx context.Context, lat, long float64, q Query) (*HourlyAirQuality, error) {
+	v, err := c.get(ctx, cacheKey("air_quality", lat, long, q), func(ctx context.Context) (any, error) {
+		return c.base.AirQuality(ctx, lat, long, q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*HourlyAirQuality), nil
+}
+
+// TemperatureEnsemble returns the cached ensemble or fetches it
+func (c *CachedProvider) TemperatureEnsemble(ctx context.Context, lat, long float64, q Query) (*Ensemble, error) {
+	v, err := c.get(ctx, cacheKey("ensemble", lat, long, q), func(ctx context.Context) (any, error) {
+		return c.base.TemperatureEnsemble(ctx, lat, long, q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Ensemble), nil
+}
+
+// Historical returns the cached archive series or fetches it
+func (c *CachedProvider) Historical(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	v, err := c.get(ctx, cacheKey("historical", lat, long, q), func(ctx context.Context) (any, error) {
+		return c.base.Historical(ctx, lat, long, q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Hourly), nil
+}
+
+// get returns a fresh cache entry, or waits for a fetch already in flight or
+// a new one whose result is stored. The fetch is shared, so it runs detached
+// from any one caller's cancellation with its own timeout, while each caller
+// stops waiting when its own context is done. Errors are not cached.
+func (c *CachedProvider) get(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.value, nil
+	}
+
+	c.mu.Lock()
+	// Another caller may have stored the series since the read lock
+	if entry, ok := c.entries[key]; ok && time.Since(entry.fetchedAt) < c.ttl {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	call, ok := c.inFlight[key]
+	if !ok {
+		call = &fetchCall{done: make(chan struct{})}
+		c.inFlight[key] = call
+		go c.fetch(context.WithoutCancel(ctx), key, call, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch runs a shared fetch and stores its result
+func (c *CachedProvider) fetch(ctx context.Context, key string, call *fetchCall, fetch func(context.Context) (any, error)) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	call.value, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	if call.err == nil {
+		// Drop expired entries so coordinates that are never asked for again don't pile up
+		for k, e := range c.entries {
+			if time.Since(e.fetchedAt) >= c.ttl {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = cacheEntry{value: call.value, fetchedAt: time.Now()}
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// cacheKey identifies a series by kind, coordinate (at API precision) and date range
+func cacheKey(kind string, lat, long float64, q Query) string {
+	return fmt.Sprintf("%s|%.4f|%.4f|%s|%s", kind, lat, long, q.StartDate, q.EndDate)
+}
@@ -0,0 +1,136 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingProvider counts forecast fetches and can be told to fail or be slow
+type countingProvider struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+	delay time.Duration
+}
+
+func (p *countingProvider) Forecast(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Hourly{Time: []string{q.StartDate + "T14:00"}, Temperature: []float64{30}}, nil
+}
+
+func (p *countingProvider) AirQuality(ctx context.Context, lat, long float64, q Query) (*HourlyAirQuality, error) {
+	return &HourlyAirQuality{}, nil
+}
+
+func (p *countingProvider) TemperatureEnsemble(ctx context.Context, lat, long float64, q Query) (*Ensemble, error) {
+	return &Ensemble{}, nil
+}
+
+func (p *countingProvider) Historical(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	return &Hourly{}, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	ctx := context.Background()
+	q := Query{StartDate: "2025-12-25", EndDate: "2025-12-31"}
+
+	t.Run("reuses series for the same coordinate and range", func(t *testing.T) {
+		base := &countingProvider{}
+		c := NewCachedProvider(base, time.Hour)
+
+		c.Forecast(ctx, 23.81031, 90.4125, q)
+		c.Forecast(ctx, 23.81029, 90.4125, q) // same coordinate at API precision
+		if base.calls != 1 {
+			t.Errorf("expected 1 fetch, got %d", base.calls)
+		}
+
+		c.Forecast(ctx, 22.3569, 91.7832, q)
+		c.Forecast(ctx, 23.8103, 90.4125, Query{})
+		if base.calls != 3 {
+			t.Errorf("expected a fetch per coordinate and range, got %d", base.calls)
+		}
+	})
+
+	t.Run("refetches after TTL", func(t *testing.T) {
+		base := &countingProvider{}
+		c := NewCachedProvider(base, 10*time.Millisecond)
+
+		c.Forecast(ctx, 23.8103, 90.4125, q)
+		time.Sleep(20 * time.Millisecond)
+		c.Forecast(ctx, 23.8103, 90.4125, q)
+		if base.calls != 2 {
+			t.Errorf("expected 2 fetches after expiry, got %d", base.calls)
+		}
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		base := &countingProvider{err: errors.New("unavailable")}
+		c := NewCachedProvider(base, time.Hour)
+
+		if _, err := c.Forecast(ctx, 23.8103, 90.4125, q); err == nil {
+			t.Fatal("expected error")
+		}
+		base.err = nil
+		if _, err := c.Forecast(ctx, 23.8103, 90.4125, q); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if base.calls != 2 {
+			t.Errorf("expected 2 fetches, got %d", base.calls)
+		}
+	})
+
+	t.Run("shares a fetch already in flight", func(t *testing.T) {
+		base := &countingProvider{delay: 20 * time.Millisecond}
+		c := NewCachedProvider(base, time.Hour)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.Forecast(ctx, 23.8103, 90.4125, q); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if base.calls != 1 {
+			t.Errorf("expected 1 shared fetch, got %d", base.calls)
+		}
+	})
+
+	t.Run("callers stop waiting on their own context", func(t *testing.T) {
+		base := &countingProvider{delay: 50 * time.Millisecond}
+		c := NewCachedProvider(base, time.Hour)
+
+		// The caller that starts the fetch gives up early
+		short, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+		defer cancel()
+		if _, err := c.Forecast(short, 23.8103, 90.4125, q); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the first caller's deadline, got %v", err)
+		}
+
+		// The shared fetch carries on for everyone else
+		h, err := c.Forecast(ctx, 23.8103, 90.4125, q)
+		if err != nil || h == nil {
+			t.Fatalf("expected the shared fetch to succeed, got %v", err)
+		}
+		if base.calls != 1 {
+			t.Errorf("expected 1 shared fetch, got %d", base.calls)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package forecast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Open-Meteo forecast horizons in days. Air-quality forecasts are shorter
+// than weather forecasts.
+const (
+	MaxForecastDays   = 16
+	MaxAirQualityDays = 7
+)
+
+// OpenMeteo fetches data from the free Open-Meteo APIs
+type OpenMeteo struct {
+	httpClient *http.Client
+}
+
+// NewOpenMeteo creates an Open-Meteo provider. A nil client gets a pooled
+// client with a 10s timeout.
+func NewOpenMeteo(httpClient *http.Client) *OpenMeteo {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		}
+	}
+
+	return &OpenMeteo{httpClient: httpClient}
+}
+
+// Forecast fetches hourly temperature and UV index
+func (o *OpenMeteo) Forecast(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	url := fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=temperature_2m,uv_index%s&timezone=auto",
+		lat, long, dateParams(q),
+	)
+
+	var data hourlyResponse
+	if err := o.get(ctx, url, "weather", &data); err != nil {
+		return nil, err
+	}
+
+	times, err := data.times("forecast")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hourly{
+		Time:        times,
+		Temperature: data.series("temperature_2m"),
+		UVIndex:     data.series("uv_index"),
+	}, nil
+}
+
+// AirQuality fetches hourly pollutant concentrations
+func (o *OpenMeteo) AirQuality(ctx context.Context, lat, long float64, q Query) (*HourlyAirQuality, error) {
+	url := fmt.Sprintf(
+		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&hourly=pm2_5,pm10,nitrogen_dioxide,ozone,sulphur_dioxide,carbon_monoxide%s&timezone=auto",
+		lat, long, dateParams(q),
+	)
+
+	var data hourlyResponse
+	if err := o.get(ctx, url, "air quality", &data); err != nil {
+		return nil, err
+	}
+
+	times, err := data.times("air quality")
+	if err != nil {
+		return nil, err
+	}
+
+	return &HourlyAirQuality{
+		Time: times,
+		PM25: data.series("pm2_5"),
+		PM10: data.series("pm10"),
+		NO2:  data.series("nitrogen_dioxide"),
+		O3:   data.series("ozone"),
+		SO2:  data.series("sulphur_dioxide"),
+		CO:   data.series("carbon_monoxide"),
+	}, nil
+}
+
+// TemperatureEnsemble fetches every ICON ensemble member's hourly temperature
+func (o *OpenMeteo) TemperatureEnsemble(ctx context.Context, lat, long float64, q Query) (*Ensemble, error) {
+	url := fmt.Sprintf(
+		"https://ensemble-api.open-meteo.com/v1/ensemble?latitude=%.4f&longitude=%.4f&hourly=temperature_2m&models=icon_seamless%s&timezone=auto",
+		lat, long, dateParams(q),
+	)
+
+	// Members come back as temperature_2m (control), temperature_2m_member01, ...
+	var data hourlyResponse
+	if err := o.get(ctx, url, "ensemble", &data); err != nil {
+		return nil, err
+	}
+
+	times, err := data.times("ensemble")
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(data.Hourly))
+	for key := range data.Hourly {
+		if strings.HasPrefix(key, "temperature_2m") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	ensemble := &Ensemble{Time: times}
+	for _, key := range keys {
+		if series := data.series(key); series != nil {
+			member := strings.TrimPrefix(strings.TrimPrefix(key, "temperature_2m"), "_")
+			if member == "" {
+				member = "control"
+			}
+			ensemble.Members = append(ensemble.Members, series)
+			ensemble.MemberKeys = append(ensemble.MemberKeys, member)
+		}
+	}
+
+	if len(ensemble.Members) == 0 {
+		return nil, fmt.Errorf("no ensemble members found")
+	}
+
+	return ensemble, nil
+}
+
+// Historical fetches observed hourly temperature from the ERA5-based archive
+func (o *OpenMeteo) Historical(ctx context.Context, lat, long float64, q Query) (*Hourly, error) {
+	url := fmt.Sprintf(
+		"https://archive-api.open-meteo.com/v1/archive?latitude=%.4f&longitude=%.4f&hourly=temperature_2m%s&timezone=auto",
+		lat, long, dateParams(q),
+	)
+
+	// The archive trails real time by a few days and reports those hours as null
+	var data hourlyResponse
+	if err := o.get(ctx, url, "archive", &data); err != nil {
+		return nil, err
+	}
+
+	times, err := data.times("archive")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hourly{Time: times, Temperature: data.series("temperature_2m")}, nil
+}
+
+// ForecastModels fetches temperature and UV index from several models in one
+// request. Open-Meteo suffixes each variable with the model name, except when
+// only one model is requested.
+func (o *OpenMeteo) ForecastModels(ctx context.Context, lat, long float64, q Query, models []string) (map[string]*Hourly, []string, error) {
+	url := fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=temperature_2m,uv_index&models=%s%s&timezone=auto",
+		lat, long, strings.Join(models, ","), dateParams(q),
+	)
+
+	var data hourlyResponse
+	if err := o.get(ctx, url, "weather", &data); err != nil {
+		return nil, nil, err
+	}
+
+	times, err := data.times("forecast")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perModel := make(map[string]*Hourly, len(models))
+	for _, model := range models {
+		suffix := "_" + model
+		if len(models) == 1 {
+			suffix = ""
+		}
+		temps := data.series("temperature_2m" + suffix)
+		if len(temps) == 0 {
+			continue
+		}
+		perModel[model] = &Hourly{Time: times, Temperature: temps, UVIndex: data.series("uv_index" + suffix)}
+	}
+
+	if len(perModel) == 0 {
+		return nil, nil, fmt.Errorf("no model returned temperature data")
+	}
+
+	return perModel, times, nil
+}
+
+// get performs a GET request and decodes the JSON body into v
+func (o *OpenMeteo) get(ctx context.Context, url, api string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s API returned status %d", api, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// dateParams renders the optional start/end date query parameters
+func dateParams(q Query) string {
+	if q.StartDate == "" || q.EndDate == "" {
+		return ""
+	}
+	return fmt.Sprintf("&start_date=%s&end_date=%s", q.StartDate, q.EndDate)
+}
+
+// hourlyResponse is the hourly block every Open-Meteo API returns, keyed by
+// variable name
+type hourlyResponse struct {
+	Hourly map[string]json.RawMessage `json:"hourly"`
+}
+
+// times returns the hourly timestamps
+func (r hourlyResponse) times(api string) ([]string, error) {
+	var times []string
+	if err := json.Unmarshal(r.Hourly["time"], &times); err != nil {
+		return nil, fmt.Errorf("%s response has no time series: %w", api, err)
+	}
+	return times, nil
+}
+
+// series returns a variable's values, or nil if it is missing or malformed
+func (r hourlyResponse) series(key string) []float64 {
+	values, err := decodeNullable(r.Hourly[key])
+	if err != nil {
+		return nil
+	}
+	return values
+}
+
+// decodeNullable decodes a series that may contain nulls. Missing values
+// become NaN so indices stay aligned with the time series.
+func decodeNullable(raw json.RawMessage) ([]float64, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var nullable []*float64
+	if err := json.Unmarshal(raw, &nullable); err != nil {
+		return nil, err
+	}
+
+	series := make([]float64, len(nullable))
+	for i, v := range nullable {
+		if v == nil {
+			series[i] = nan
+		} else {
+			series[i] = *v
+		}
+	}
+	return series, nil
+}
@@ -0,0 +1,128 @@
+package forecast
+
+import (
+	"context"
+	"math"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/aqi"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+// nan marks a missing value in an hourly series
+var nan = math.NaN()
+
+// Query limits a request to a date range. Empty dates use the provider's
+// default forecast horizon.
+type Query struct {
+	StartDate string // Format: YYYY-MM-DD
+	EndDate   string // Format: YYYY-MM-DD
+}
+
+// Hourly is an hourly temperature and UV index series
+type Hourly struct {
+	Time        []string
+	Temperature []float64
+	UVIndex     []float64
+	// Models holds each model's own series when several models were blended
+	Models      map[string]*Hourly
+	BlendMethod string
+}
+
+// HourlyAirQuality is an hourly pollutant series in µg/m³
+type HourlyAirQuality struct {
+	Time []string
+	PM25 []float64
+	PM10 []float64
+	NO2  []float64
+	O3   []float64
+	SO2  []float64
+	CO   []float64
+}
+
+// Ensemble holds every ensemble member's hourly temperature series
+type Ensemble struct {
+	Time    []string
+	Members [][]float64
+	// MemberKeys names each member, e.g. "control" or "member01", so members
+	// can be matched across locations
+	MemberKeys []string
+}
+
+// Provider fetches hourly weather and air-quality series for a coordinate
+type Provider interface {
+	Forecast(ctx context.Context, lat, long float64, q Query) (*Hourly, error)
+	AirQuality(ctx context.Context, lat, long float64, q Query) (*HourlyAirQuality, error)
+	TemperatureEnsemble(ctx context.Context, lat, long float64, q Query) (*Ensemble, error)
+	// Historical returns observed (reanalysis) hourly temperature for past dates.
+	// UV index is not archived, so Hourly.UVIndex is empty.
+	Historical(ctx context.Context, lat, long float64, q Query) (*Hourly, error)
+}
+
+// HourIndices returns the indices of timestamps at the given hour of day.
+// Timestamps are in the provider's local format, e.g. "2025-12-25T14:00".
+func HourIndices(times []string, hour int) []int {
+	want := []byte{byte('0' + hour/10), byte('0' + hour%10)}
+
+	var indices []int
+	for i, timeStr := range times {
+		if len(timeStr) >= 13 && timeStr[11] == want[0] && timeStr[12] == want[1] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ValuesAt returns series values at the given indices, skipping indices that
+// are out of range or missing
+func ValuesAt(series []float64, indices []int) []float64 {
+	var values []float64
+	for _, i := range indices {
+		if i < len(series) && !math.IsNaN(series[i]) {
+			values = append(values, series[i])
+		}
+	}
+	return values
+}
+
+// AQIInputs averages each pollutant over the period its AQI breakpoints are
+// defined for, taken at index i: the calendar day for 24-hour periods, as the
+// daily AQI uses, otherwise the hours ending at i. Missing hours are skipped,
+// and a pollutant without any values is 0.
+func (h *HourlyAirQuality) AQIInputs(i int) types.Pollutants {
+	mean := func(key string, series []float64) float64 {
+		from, to := max(i-aqi.AveragingHours(key)+1, 0), i
+		if aqi.AveragingHours(key) == 24 && i < len(h.Time) && len(h.Time[i]) >= 10 {
+			day := h.Time[i][:10]
+			from = i
+			for from > 0 && len(h.Time[from-1]) >= 10 && h.Time[from-1][:10] == day {
+				from--
+			}
+			for to+1 < len(h.Time) && len(h.Time[to+1]) >= 10 && h.Time[to+1][:10] == day {
+				to++
+			}
+		}
+
+		var sum float64
+		n := 0
+		for j := from; j <= to && j < len(series); j++ {
+			if !math.IsNaN(series[j]) {
+				sum += series[j]
+				n++
+			}
+		}
+		if n == 0 {
+			return 0
+		}
+		return sum / float64(n)
+	}
+
+	return types.Pollutants{
+		PM25: mean(pollutant.PM25, h.PM25),
+		PM10: mean(pollutant.PM10, h.PM10),
+		NO2:  mean(pollutant.NO2, h.NO2),
+		O3:   mean(pollutant.O3, h.O3),
+		SO2:  mean(pollutant.SO2, h.SO2),
+		CO:   mean(pollutant.CO, h.CO),
+	}
+}
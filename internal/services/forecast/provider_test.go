@@ -0,0 +1,45 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestAQIInputs(t *testing.T) {
+	// Two days of hours; each pollutant's value is its hour of the series
+	h := &HourlyAirQuality{}
+	for i := range 48 {
+		h.Time = append(h.Time, fmt.Sprintf("2025-12-%02dT%02d:00", 25+i/24, i%24))
+		for _, series := range []*[]float64{&h.PM25, &h.PM10, &h.NO2, &h.O3, &h.SO2, &h.CO} {
+			*series = append(*series, float64(i))
+		}
+	}
+	h.O3[36] = nan
+
+	// 2PM on the second day
+	p := h.AQIInputs(38)
+
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"PM2.5 over the calendar day", p.PM25, 35.5},
+		{"PM10 over the calendar day", p.PM10, 35.5},
+		{"CO over the 8 hours ending at 2PM", p.CO, 34.5},
+		{"O3 skips the missing hour", p.O3, (31 + 32 + 33 + 34 + 35 + 37 + 38) / 7.0},
+		{"NO2 at 2PM", p.NO2, 38},
+		{"SO2 at 2PM", p.SO2, 38},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.expected) > 1e-9 {
+			t.Errorf("%s: expected %.2f, got %.2f", tt.name, tt.expected, tt.got)
+		}
+	}
+
+	// Near the start of the series the 8-hour window is shorter
+	if p := h.AQIInputs(2); p.CO != 1 {
+		t.Errorf("expected the CO window to be cut at the start of the series, got %.2f", p.CO)
+	}
+}
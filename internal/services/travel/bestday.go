@@ -0,0 +1,244 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/services/forecast"
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/aqi"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+// dayWeather is one day's 2PM conditions from a forecast window
+type dayWeather struct {
+	date          string
+	weather       types.LocationWeather
+	hasAirQuality bool
+}
+
+// GetBestDay scores every day in the forecast window for a trip to the
+// destination and picks the best one
+func (s *TravelService) GetBestDay(ctx context.Context, req types.BestDayRequest) (*types.BestDayResponse, error) {
+	origin, err := s.locateOrigin(req.CurrentLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := s.resolveDestination(req.DestinationDistrictID, req.DestinationDistrictName, req.DestinationUpazila, req.DestinationLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	pollutants, err := pollutant.Normalize(req.Pollutants)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := LookupProfile(req.TravelerProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	type windowResult struct {
+		days []dayWeather
+		err  error
+	}
+
+	currentCh := make(chan windowResult, 1)
+	destCh := make(chan windowResult, 1)
+
+	go func() {
+		days, err := s.fetchForecastWindow(ctx, req.CurrentLocation.Lat, req.CurrentLocation.Long)
+		currentCh <- windowResult{days: days, err: err}
+	}()
+
+	go func() {
+		days, err := s.fetchForecastWindow(ctx, destination.Lat, destination.Long)
+		destCh <- windowResult{days: days, err: err}
+	}()
+
+	currentResult := <-currentCh
+	destResult := <-destCh
+
+	if currentResult.err != nil {
+		return nil, fmt.Errorf("failed to fetch current location weather: %w", currentResult.err)
+	}
+	if destResult.err != nil {
+		return nil, fmt.Errorf("failed to fetch destination weather: %w", destResult.err)
+	}
+
+	currentName := req.CurrentLocation.Name
+	if currentName == "" {
+		currentName = "Current Location"
+	}
+
+	currentByDate := make(map[string]dayWeather, len(currentResult.days))
+	for _, d := range currentResult.days {
+		currentByDate[d.date] = d
+	}
+
+	now := today()
+	missingAirQuality := fmt.Sprintf("air quality is only forecast %d days ahead", s.horizon.AirQualityDays)
+
+	var days []types.TravelRecommendation
+	best := -1
+	for _, dest := range destResult.days {
+		current, ok := currentByDate[dest.date]
+		if !ok {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", dest.date)
+		if err != nil {
+			continue
+		}
+		leadDays := max(0, int(date.Sub(now).Hours()/24))
+
+		c := comparison{
+			date:       dest.date,
+			basis:      BasisForecast,
+			pollutants: pollutants,
+			profile:    profile,
+			confidence: forecastConfidence(leadDays, nil, nil, nil),
+		}
+		if !current.hasAirQuality || !dest.hasAirQuality {
+			c.missingAirQuality = missingAirQuality
+		}
+
+		current.weather.Name = currentName
+		dest.weather.Name = destination.Name
+		dest.weather.District = destination.DistrictName
+		days = append(days, *s.compare(current.weather, dest.weather, c))
+
+		// Ties go to the earlier day
+		if best < 0 || ranksAbove(days[len(days)-1], days[best]) {
+			best = len(days) - 1
+		}
+	}
+
+	if best < 0 {
+		return nil, fmt.Errorf("no forecast days available for both locations")
+	}
+
+	reason := fmt.Sprintf("%s is the best day to visit in the next %d days. %s", days[best].TravelDate, len(days), days[best].Reason)
+	if !days[best].TemperatureOnly && days[len(days)-1].TemperatureOnly {
+		reason += fmt.Sprintf(" Days beyond the %d-day air-quality forecast compare temperature only, so they rank after fully compared days.", s.horizon.AirQualityDays)
+	}
+
+	return &types.BestDayResponse{
+		Destination:    destination.Name,
+		Origin:         origin,
+		BestDay:        days[best].TravelDate,
+		Recommendation: days[best].Recommendation,
+		Reason:         reason,
+		Days:           days,
+	}, nil
+}
+
+// ranksAbove reports whether day a is a better pick than day b. Scores on
+// fewer factors aren't comparable with full comparisons, so days with air
+// quality rank above temperature-only days and scores only decide within a basis.
+func ranksAbove(a, b types.TravelRecommendation) bool {
+	if a.TemperatureOnly != b.TemperatureOnly {
+		return !a.TemperatureOnly
+	}
+	return a.Score > b.Score
+}
+
+// fetchForecastWindow fetches 2PM conditions for every day in the forecast
+// window. Days beyond the air-quality horizon have no pollutant values. Both
+// series are requested for fixed date ranges so the provider can cache them.
+func (s *TravelService) fetchForecastWindow(ctx context.Context, lat, long float64) ([]dayWeather, error) {
+	forecastQuery, airQualityQuery := s.ForecastWindow()
+
+	type forecastResult struct {
+		hourly *forecast.Hourly
+		err    error
+	}
+	type airQualityResult struct {
+		hourly *forecast.HourlyAirQuality
+		err    error
+	}
+
+	forecastCh := make(chan forecastResult, 1)
+	aqCh := make(chan airQualityResult, 1)
+
+	go func() {
+		hourly, err := s.provider.Forecast(ctx, lat, long, forecastQuery)
+		forecastCh <- forecastResult{hourly: hourly, err: err}
+	}()
+
+	go func() {
+		hourly, err := s.provider.AirQuality(ctx, lat, long, airQualityQuery)
+		aqCh <- airQualityResult{hourly: hourly, err: err}
+	}()
+
+	fc := <-forecastCh
+	aqResult := <-aqCh
+
+	if fc.err != nil {
+		return nil, fc.err
+	}
+
+	// Air quality is supplementary here; without it every day is temperature-only
+	type dayAirQuality struct {
+		at2PM  types.Pollutants
+		report types.AQIReport
+	}
+	aqByDate := make(map[string]dayAirQuality)
+	if aqResult.err == nil {
+		aqHourly := aqResult.hourly
+		for _, i := range forecast.HourIndices(aqHourly.Time, 14) {
+			pm25 := forecast.ValuesAt(aqHourly.PM25, []int{i})
+			if len(pm25) == 0 {
+				continue
+			}
+			at2PM := types.Pollutants{
+				PM25: round2(pm25[0]),
+				PM10: firstValue(forecast.ValuesAt(aqHourly.PM10, []int{i})),
+				NO2:  firstValue(forecast.ValuesAt(aqHourly.NO2, []int{i})),
+				O3:   firstValue(forecast.ValuesAt(aqHourly.O3, []int{i})),
+				SO2:  firstValue(forecast.ValuesAt(aqHourly.SO2, []int{i})),
+				CO:   firstValue(forecast.ValuesAt(aqHourly.CO, []int{i})),
+			}
+			aqByDate[aqHourly.Time[i][:10]] = dayAirQuality{at2PM: at2PM, report: aqi.Report(aqHourly.AQIInputs(i))}
+		}
+	}
+
+	var days []dayWeather
+	for _, i := range forecast.HourIndices(fc.hourly.Time, 14) {
+		temp := forecast.ValuesAt(fc.hourly.Temperature, []int{i})
+		if len(temp) == 0 {
+			continue
+		}
+		uv := forecast.ValuesAt(fc.hourly.UVIndex, []int{i})
+
+		day := dayWeather{
+			date: fc.hourly.Time[i][:10],
+			weather: types.LocationWeather{
+				Temp2PM:    round2(temp[0]),
+				UVIndex:    uvIndex(uv),
+				UVCategory: uvCategory(uv),
+			},
+		}
+		if aq, ok := aqByDate[day.date]; ok {
+			day.hasAirQuality = true
+			day.weather.PM25 = aq.at2PM.PM25
+			day.weather.PM10 = aq.at2PM.PM10
+			day.weather.NO2 = aq.at2PM.NO2
+			day.weather.O3 = aq.at2PM.O3
+			day.weather.SO2 = aq.at2PM.SO2
+			day.weather.CO = aq.at2PM.CO
+			day.weather.AQI = aq.report
+		}
+		days = append(days, day)
+	}
+
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no 2PM temperature data found")
+	}
+
+	return days, nil
+}
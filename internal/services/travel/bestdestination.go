@@ -0,0 +1,277 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+// districtWindow is a district's forecast window or the error fetching it
+type districtWindow struct {
+	district types.District
+	days     []dayWeather
+	err      error
+}
+
+// GetBestDestinations ranks every district by how much cooler and cleaner it
+// is than the current location on the travel date. When the request names a
+// district, that district's upazilas are ranked instead.
+func (s *TravelService) GetBestDestinations(ctx context.Context, req types.BestDestinationsRequest) (*types.DestinationRanking, error) {
+	travelDate, err := time.Parse("2006-01-02", req.TravelDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid travel date format, use YYYY-MM-DD")
+	}
+
+	// Only the forecast window is cached per district
+	now := today()
+	leadDays := int(travelDate.Sub(now).Hours() / 24)
+	if travelDate.Before(now) || leadDays >= s.horizon.ForecastDays {
+		return nil, fmt.Errorf("travel date must be within the next %d days", s.horizon.ForecastDays)
+	}
+
+	location, err := s.locateOrigin(req.CurrentLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	pollutants, err := pollutant.Normalize(req.Pollutants)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := LookupProfile(req.TravelerProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	places := s.districts.Districts()
+	var parent types.District
+	if req.District != "" {
+		parent, err = s.resolveDistrict(req.District)
+		if err != nil {
+			return nil, err
+		}
+		places, err = s.upazilaPlaces(parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// One fetch for the origin, district windows come from the series cache
+	originCh := make(chan districtWindow, 1)
+	go func() {
+		days, err := s.fetchForecastWindow(ctx, req.CurrentLocation.Lat, req.CurrentLocation.Long)
+		originCh <- districtWindow{days: days, err: err}
+	}()
+
+	windows := s.fetchDistrictWindows(ctx, places)
+
+	originWindow := <-originCh
+	if originWindow.err != nil {
+		return nil, fmt.Errorf("failed to fetch current location weather: %w", originWindow.err)
+	}
+
+	origin, ok := dayOf(originWindow.days, req.TravelDate)
+	if !ok {
+		return nil, fmt.Errorf("no forecast for the current location on %s", req.TravelDate)
+	}
+	origin.weather.Name = req.CurrentLocation.Name
+	if origin.weather.Name == "" {
+		origin.weather.Name = "Current Location"
+	}
+
+	confidence := forecastConfidence(leadDays, nil, nil, nil)
+	missingAirQuality := fmt.Sprintf("air quality is only forecast %d days ahead", s.horizon.AirQualityDays)
+
+	var destinations []types.DestinationComparison
+	for _, w := range windows {
+		if w.err != nil {
+			slog.Warn("skipping district without forecast", "district", w.district.Name, "error", w.err)
+			continue
+		}
+
+		dest, ok := dayOf(w.days, req.TravelDate)
+		if !ok {
+			continue
+		}
+		dest.weather.Name = w.district.Name
+
+		c := comparison{
+			date:       req.TravelDate,
+			basis:      BasisForecast,
+			pollutants: pollutants,
+			profile:    profile,
+			confidence: confidence,
+		}
+		if !origin.hasAirQuality || !dest.hasAirQuality {
+			c.missingAirQuality = missingAirQuality
+		}
+
+		rec := s.compare(origin.weather, dest.weather, c)
+		result := destinationComparison(w.district, rec)
+		result.District = parent.Name
+		destinations = append(destinations, result)
+	}
+
+	if len(destinations) == 0 {
+		return nil, fmt.Errorf("failed to fetch district weather")
+	}
+
+	rankDestinations(destinations)
+
+	comparedPollutants := pollutants
+	if !origin.hasAirQuality {
+		comparedPollutants = []string{}
+	}
+
+	return &types.DestinationRanking{
+		TravelDate:      req.TravelDate,
+		District:        parent.Name,
+		CurrentWeather:  origin.weather,
+		Origin:          location,
+		Pollutants:      comparedPollutants,
+		TravelerProfile: profile.Name,
+		Destinations:    destinations,
+	}, nil
+}
+
+// WarmCache pre-fetches every district's forecast window into the series cache
+func (s *TravelService) WarmCache(ctx context.Context) error {
+	for _, w := range s.fetchDistrictWindows(ctx, s.districts.Districts()) {
+		if w.err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to fetch any district forecast")
+}
+
+// StartBackgroundRefresh re-fetches every district's forecast window
+// periodically, so the series cache stays warm after the startup warm-up
+// expires. Set the interval below the cache TTL.
+func (s *TravelService) StartBackgroundRefresh(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Background refresh - don't block on errors
+				refreshCtx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+				if err := s.WarmCache(refreshCtx); err != nil {
+					slog.Warn("failed to refresh district forecasts", "error", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// upazilaPlaces lists a district's upazilas as places to rank, carrying the
+// district's division
+func (s *TravelService) upazilaPlaces(district types.District) ([]types.District, error) {
+	upazilas := s.districts.UpazilasIn(district.ID)
+	if len(upazilas) == 0 {
+		return nil, s.noUpazilaData(district)
+	}
+
+	places := make([]types.District, len(upazilas))
+	for i, u := range upazilas {
+		places[i] = types.District{
+			ID:           u.ID,
+			DivisionID:   district.DivisionID,
+			DivisionName: district.DivisionName,
+			Name:         u.Name,
+			BnName:       u.BnName,
+			Lat:          u.Lat,
+			Long:         u.Long,
+		}
+	}
+	return places, nil
+}
+
+// fetchDistrictWindows fetches the given districts' forecast windows
+// concurrently
+func (s *TravelService) fetchDistrictWindows(ctx context.Context, districts []types.District) []districtWindow {
+	results := make(chan districtWindow, len(districts))
+	var wg sync.WaitGroup
+
+	// Use a semaphore to limit concurrent requests (avoid rate limiting)
+	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
+
+	for _, district := range districts {
+		wg.Add(1)
+		go func(d types.District) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			days, err := s.fetchForecastWindow(ctx, d.Lat, d.Long)
+			results <- districtWindow{district: d, days: days, err: err}
+		}(district)
+	}
+
+	wg.Wait()
+	close(results)
+
+	windows := make([]districtWindow, 0, len(districts))
+	for w := range results {
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+// destinationComparison condenses a recommendation into a ranking entry
+func destinationComparison(district types.District, rec *types.TravelRecommendation) types.DestinationComparison {
+	return types.DestinationComparison{
+		ID:                   district.ID,
+		Name:                 rec.DestinationWeather.Name,
+		Division:             district.DivisionName,
+		Recommendation:       rec.Recommendation,
+		Score:                rec.Score,
+		Reason:               rec.Reason,
+		Basis:                rec.Basis,
+		TemperatureOnly:      rec.TemperatureOnly,
+		TempDifference:       rec.TempDifference,
+		PollutantDifferences: rec.PollutantDifferences,
+		AQIDifference:        rec.AQIDifference,
+		UVIndexDifference:    rec.UVIndexDifference,
+		Destination:          rec.DestinationWeather,
+	}
+}
+
+// rankDestinations sorts best score first, ties going to the bigger
+// temperature drop and then by name, and numbers the ranks
+func rankDestinations(destinations []types.DestinationComparison) {
+	sort.Slice(destinations, func(i, j int) bool {
+		if destinations[i].Score != destinations[j].Score {
+			return destinations[i].Score > destinations[j].Score
+		}
+		if destinations[i].TempDifference != destinations[j].TempDifference {
+			return destinations[i].TempDifference > destinations[j].TempDifference
+		}
+		return destinations[i].Name < destinations[j].Name
+	})
+	for i := range destinations {
+		destinations[i].Rank = i + 1
+	}
+}
+
+// dayOf finds a date in a forecast window
+func dayOf(days []dayWeather, date string) (dayWeather, bool) {
+	for _, d := range days {
+		if d.date == date {
+			return d, true
+		}
+	}
+	return dayWeather{}, false
+}
@@ -0,0 +1,92 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+const (
+	// minCandidates and maxCandidates bound a comparison shortlist
+	minCandidates = 2
+	maxCandidates = 5
+)
+
+// CompareDestinations compares a shortlist of districts with the current
+// location on one date and ranks them. Each candidate is evaluated exactly
+// like a single recommendation; the origin's series is fetched once and shared
+// through the series cache.
+func (s *TravelService) CompareDestinations(ctx context.Context, req types.CompareRequest) (*types.DestinationRanking, error) {
+	names := req.DestinationDistrictNames
+	if len(names) < minCandidates || len(names) > maxCandidates {
+		return nil, fmt.Errorf("compare between %d and %d destination districts", minCandidates, maxCandidates)
+	}
+
+	// Candidates can be listed by ID or English or Bangla name
+	candidates := make([]types.District, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		d, err := s.resolveDistrict(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[d.ID] {
+			return nil, fmt.Errorf("destination district listed twice: %s", d.Name)
+		}
+		seen[d.ID] = true
+		candidates[i] = d
+	}
+
+	type candidateResult struct {
+		index          int
+		recommendation *types.TravelRecommendation
+		err            error
+	}
+
+	results := make(chan candidateResult, len(candidates))
+	for i, d := range candidates {
+		candidateReq := types.TravelRequest{
+			CurrentLocation:       req.CurrentLocation,
+			DestinationDistrictID: d.ID,
+			TravelDate:            req.TravelDate,
+			Pollutants:            req.Pollutants,
+			TravelerProfile:       req.TravelerProfile,
+		}
+
+		go func() {
+			rec, err := s.GetRecommendation(ctx, candidateReq)
+			results <- candidateResult{index: i, recommendation: rec, err: err}
+		}()
+	}
+
+	recs := make([]*types.TravelRecommendation, len(candidates))
+	var errs []error
+	for range candidates {
+		r := <-results
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		recs[r.index] = r.recommendation
+	}
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	destinations := make([]types.DestinationComparison, len(recs))
+	for i, rec := range recs {
+		destinations[i] = destinationComparison(candidates[i], rec)
+	}
+	rankDestinations(destinations)
+
+	first := recs[0]
+	return &types.DestinationRanking{
+		TravelDate:      req.TravelDate,
+		CurrentWeather:  first.CurrentWeather,
+		Origin:          first.Origin,
+		Pollutants:      first.Pollutants,
+		TravelerProfile: first.TravelerProfile.Name,
+		Destinations:    destinations,
+	}, nil
+}
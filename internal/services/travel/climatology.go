@@ -0,0 +1,189 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/services/forecast"
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/aqi"
+)
+
+const (
+	// climateYears is how many past years of temperature go into a climate normal
+	climateYears = 10
+	// climateAirQualityYears is shorter because global air-quality history only
+	// goes back a few years
+	climateAirQualityYears = 3
+	// climateWindowDays widens each year's sample to ±N days around the calendar
+	// day so a single unusual day doesn't dominate the normal
+	climateWindowDays = 3
+	// climateMaxDays is how far ahead climatology answers are given
+	climateMaxDays = 365
+	// archiveLagDays is how far the historical archive trails real time
+	archiveLagDays = 5
+)
+
+// fetchClimateNormals estimates 2PM temperature and pollutant levels for a
+// calendar day from the same days in past years. Each source is fetched in one
+// ranged request covering all the years, keeping only the days around the
+// calendar day. Air quality is optional: without its history the normal has
+// no pollutants and only temperature is compared. UV index is not archived, so
+// it is left out.
+func (s *TravelService) fetchClimateNormals(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return types.LocationWeather{}, err
+	}
+
+	type airQualityResult struct {
+		values []types.Pollutants // At 2PM
+		inputs []types.Pollutants // Averaged for the AQI
+		err    error
+	}
+
+	aqCh := make(chan airQualityResult, 1)
+	go func() {
+		hourly, err := s.provider.AirQuality(ctx, lat, long, climateQuery(day, climateAirQualityYears))
+		if err != nil {
+			aqCh <- airQualityResult{err: err}
+			return
+		}
+
+		var values, inputs []types.Pollutants
+		for _, i := range climateIndices(hourly.Time, day, climateAirQualityYears) {
+			pm25 := forecast.ValuesAt(hourly.PM25, []int{i})
+			if len(pm25) == 0 {
+				continue
+			}
+			values = append(values, types.Pollutants{
+				PM25: pm25[0],
+				PM10: firstValue(forecast.ValuesAt(hourly.PM10, []int{i})),
+				NO2:  firstValue(forecast.ValuesAt(hourly.NO2, []int{i})),
+				O3:   firstValue(forecast.ValuesAt(hourly.O3, []int{i})),
+				SO2:  firstValue(forecast.ValuesAt(hourly.SO2, []int{i})),
+				CO:   firstValue(forecast.ValuesAt(hourly.CO, []int{i})),
+			})
+			inputs = append(inputs, hourly.AQIInputs(i))
+		}
+		aqCh <- airQualityResult{values: values, inputs: inputs}
+	}()
+
+	hourly, err := s.provider.Historical(ctx, lat, long, climateQuery(day, climateYears))
+	aqResult := <-aqCh
+
+	if ctx.Err() != nil {
+		return types.LocationWeather{}, ctx.Err()
+	}
+	if err != nil {
+		return types.LocationWeather{}, err
+	}
+
+	temps := forecast.ValuesAt(hourly.Temperature, climateIndices(hourly.Time, day, climateYears))
+	if len(temps) == 0 {
+		return types.LocationWeather{}, fmt.Errorf("no historical 2PM temperature data found")
+	}
+
+	var sum float64
+	for _, t := range temps {
+		sum += t
+	}
+	weather := types.LocationWeather{Temp2PM: round2(sum / float64(len(temps)))}
+
+	// Air quality is left empty when its history is unavailable
+	if aqResult.err != nil || len(aqResult.values) == 0 {
+		return weather, nil
+	}
+
+	aq := meanPollutants(aqResult.values)
+	weather.PM25 = aq.PM25
+	weather.PM10 = aq.PM10
+	weather.NO2 = aq.NO2
+	weather.O3 = aq.O3
+	weather.SO2 = aq.SO2
+	weather.CO = aq.CO
+	weather.AQI = aqi.Report(meanPollutants(aqResult.inputs))
+	return weather, nil
+}
+
+// meanPollutants averages pollutant levels, rounded to 2 decimal places
+func meanPollutants(values []types.Pollutants) types.Pollutants {
+	var sum types.Pollutants
+	for _, p := range values {
+		sum.PM25 += p.PM25
+		sum.PM10 += p.PM10
+		sum.NO2 += p.NO2
+		sum.O3 += p.O3
+		sum.SO2 += p.SO2
+		sum.CO += p.CO
+	}
+	n := float64(len(values))
+	return types.Pollutants{
+		PM25: round2(sum.PM25 / n),
+		PM10: round2(sum.PM10 / n),
+		NO2:  round2(sum.NO2 / n),
+		O3:   round2(sum.O3 / n),
+		SO2:  round2(sum.SO2 / n),
+		CO:   round2(sum.CO / n),
+	}
+}
+
+// climateYearRange returns how many years back the first and last samples of
+// a normal are. Normally that is last year to years ago, but for dates almost
+// a year ahead last year's window isn't archived yet, so the years shift back
+// by one.
+func climateYearRange(day time.Time, years int) (int, int) {
+	archived := today().AddDate(0, 0, -archiveLagDays)
+	if day.AddDate(-1, 0, climateWindowDays).After(archived) {
+		return 2, years + 1
+	}
+	return 1, years
+}
+
+// climateQuery spans the calendar day's windows over the past years, from the
+// oldest year to the most recent archived one
+func climateQuery(day time.Time, years int) forecast.Query {
+	first, last := climateYearRange(day, years)
+	return forecast.Query{
+		StartDate: day.AddDate(-last, 0, -climateWindowDays).Format("2006-01-02"),
+		EndDate:   day.AddDate(-first, 0, climateWindowDays).Format("2006-01-02"),
+	}
+}
+
+// climateIndices returns the 2PM indices that fall within ±climateWindowDays
+// of the calendar day in one of the past years
+func climateIndices(times []string, day time.Time, years int) []int {
+	first, last := climateYearRange(day, years)
+	window := make(map[string]bool, years*(2*climateWindowDays+1))
+	for year := first; year <= last; year++ {
+		for d := -climateWindowDays; d <= climateWindowDays; d++ {
+			window[day.AddDate(-year, 0, d).Format("2006-01-02")] = true
+		}
+	}
+
+	var indices []int
+	for _, i := range forecast.HourIndices(times, 14) {
+		if window[times[i][:10]] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// hasAirQuality reports whether the weather carries pollutant data
+func hasAirQuality(w types.LocationWeather) bool {
+	return w.AQI.USEPA.Category != ""
+}
+
+// climatologyConfidence rates a climate-normal answer. Normals describe a
+// typical year, not the actual weather on the day, so confidence is always low.
+func climatologyConfidence() types.ForecastConfidence {
+	return types.ForecastConfidence{
+		Level: ConfidenceLow,
+		Score: 0.2,
+		Reasons: []string{
+			fmt.Sprintf("date is beyond the forecast horizon, based on %d-year climate normals", climateYears),
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shuv1824/recommender/internal/services/forecast"
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// Forecast confidence levels
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// leadDayPenalty is how much confidence is lost per day of forecast lead time
+const leadDayPenalty = 0.08
+
+// fetchTemperatureEnsemble fetches every ensemble member's 2PM temperature for
+// a specific date, keyed by member. Members without a 2PM value are left out.
+func (s *TravelService) fetchTemperatureEnsemble(ctx context.Context, lat, long float64, date string) (map[string]float64, error) {
+	ensemble, err := s.provider.TemperatureEnsemble(ctx, lat, long, forecast.Query{StartDate: date, EndDate: date})
+	if err != nil {
+		return nil, err
+	}
+
+	indices := forecast.HourIndices(ensemble.Time, 14)
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no 2PM ensemble data found")
+	}
+
+	members := make(map[string]float64, len(ensemble.Members))
+	for i, series := range ensemble.Members {
+		if i >= len(ensemble.MemberKeys) {
+			break
+		}
+		if values := forecast.ValuesAt(series, indices[:1]); len(values) > 0 {
+			members[ensemble.MemberKeys[i]] = values[0]
+		}
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no 2PM ensemble data found")
+	}
+
+	return members, nil
+}
+
+// memberValues lists ensemble member values in member order
+func memberValues(members map[string]float64) []float64 {
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]float64, len(keys))
+	for i, key := range keys {
+		values[i] = members[key]
+	}
+	return values
+}
+
+// ensembleStats summarizes ensemble member values
+func ensembleStats(members []float64) *types.EnsembleStats {
+	sorted := make([]float64, len(members))
+	copy(sorted, members)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	var variance float64
+	for _, v := range sorted {
+		variance += (v - mean) * (v - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(sorted)))
+
+	p10 := percentile(sorted, 10)
+	p90 := percentile(sorted, 90)
+
+	return &types.EnsembleStats{
+		Members: len(sorted),
+		Mean:    round2(mean),
+		StdDev:  round2(stdDev),
+		Min:     round2(sorted[0]),
+		P10:     round2(p10),
+		P50:     round2(percentile(sorted, 50)),
+		P90:     round2(p90),
+		Max:     round2(sorted[len(sorted)-1]),
+		Spread:  round2(p90 - p10),
+	}
+}
+
+// percentile linearly interpolates the p-th percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	frac := rank - float64(lower)
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
+
+// forecastConfidence rates how far the recommendation can be trusted. Confidence
+// falls with lead time and, when ensembles are available, with member spread
+// and with disagreement over whether the destination is cooler.
+func forecastConfidence(leadDays int, current, dest map[string]float64, ensembleErr error) types.ForecastConfidence {
+	score := math.Max(0.2, 1-leadDayPenalty*float64(leadDays))
+	forecastRange := forecastRange(leadDays)
+	reasons := []string{fmt.Sprintf("forecast lead time is %d day(s) (%s range)", leadDays, forecastRange)}
+
+	switch {
+	case ensembleErr != nil:
+		reasons = append(reasons, "ensemble forecast unavailable, lead time only")
+	case len(current) > 0 && len(dest) > 0:
+		currentStats := ensembleStats(memberValues(current))
+		destStats := ensembleStats(memberValues(dest))
+
+		// A P10-P90 width of 5°C halves confidence
+		avgSpread := (currentStats.Spread + destStats.Spread) / 2
+		score *= 1 / (1 + avgSpread/5)
+		reasons = append(reasons, fmt.Sprintf("ensemble P10-P90 spread averages %.1f°C", avgSpread))
+
+		// Pair members by key; both locations come from the same model run. A
+		// member missing at either location drops the pair.
+		n, cooler := 0, 0
+		for key, c := range current {
+			d, ok := dest[key]
+			if !ok {
+				continue
+			}
+			n++
+			if d < c {
+				cooler++
+			}
+		}
+		if n == 0 {
+			reasons = append(reasons, "no ensemble members cover both locations")
+			break
+		}
+		agreement := math.Max(float64(cooler), float64(n-cooler)) / float64(n)
+		score *= agreement
+
+		if cooler*2 >= n {
+			reasons = append(reasons, fmt.Sprintf("%.0f%% of members show the destination cooler", float64(cooler)/float64(n)*100))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("%.0f%% of members show the destination warmer", float64(n-cooler)/float64(n)*100))
+		}
+	}
+
+	level := ConfidenceLow
+	if score >= 0.7 {
+		level = ConfidenceHigh
+	} else if score >= 0.4 {
+		level = ConfidenceMedium
+	}
+
+	return types.ForecastConfidence{
+		Level:   level,
+		Score:   round2(score),
+		Range:   forecastRange,
+		Reasons: reasons,
+	}
+}
+
+// round2 rounds to 2 decimal places
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,182 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// What a group trip is optimized for
+const (
+	OptimizeAverage  = "average"
+	OptimizeWorstOff = "worst_off"
+)
+
+const (
+	// maxGroupOrigins and maxGroupDestinations bound a group trip, which
+	// compares every origin with every destination
+	maxGroupOrigins      = 10
+	maxGroupDestinations = 10
+)
+
+// GetGroupTrip grades candidate destinations for travelers coming from several
+// origins. Every origin is compared with every destination exactly like a
+// single recommendation; destinations are then ranked by the group's average
+// score or by the score of the worst-off traveler.
+func (s *TravelService) GetGroupTrip(ctx context.Context, req types.GroupTripRequest) (*types.GroupTripResponse, error) {
+	optimizeFor := req.OptimizeFor
+	if optimizeFor == "" {
+		optimizeFor = OptimizeAverage
+	}
+	if optimizeFor != OptimizeAverage && optimizeFor != OptimizeWorstOff {
+		return nil, fmt.Errorf("unknown optimize_for %q, use %s or %s", req.OptimizeFor, OptimizeAverage, OptimizeWorstOff)
+	}
+
+	if len(req.Origins) < 2 || len(req.Origins) > maxGroupOrigins {
+		return nil, fmt.Errorf("group trips must have between 2 and %d origins", maxGroupOrigins)
+	}
+	if len(req.DestinationDistrictNames) == 0 || len(req.DestinationDistrictNames) > maxGroupDestinations {
+		return nil, fmt.Errorf("group trips must have between 1 and %d destination districts", maxGroupDestinations)
+	}
+
+	// Candidates can be listed by ID or English or Bangla name
+	candidates := make([]types.District, len(req.DestinationDistrictNames))
+	seen := make(map[string]bool, len(candidates))
+	for i, name := range req.DestinationDistrictNames {
+		d, err := s.resolveDistrict(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[d.ID] {
+			return nil, fmt.Errorf("destination district listed twice: %s", d.Name)
+		}
+		seen[d.ID] = true
+		candidates[i] = d
+	}
+
+	// Unnamed origins are numbered so travelers can be told apart
+	origins := make([]types.Location, len(req.Origins))
+	for i, o := range req.Origins {
+		if o.Name == "" {
+			o.Name = fmt.Sprintf("Origin %d", i+1)
+		}
+		origins[i] = o
+	}
+
+	type pairResult struct {
+		dest, origin   int
+		recommendation *types.TravelRecommendation
+		err            error
+	}
+
+	results := make(chan pairResult, len(candidates)*len(origins))
+	var wg sync.WaitGroup
+
+	// Use a semaphore to limit concurrent requests (avoid rate limiting)
+	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
+
+	for d, candidate := range candidates {
+		for o, origin := range origins {
+			pairReq := types.TravelRequest{
+				CurrentLocation:       origin,
+				DestinationDistrictID: candidate.ID,
+				TravelDate:            req.TravelDate,
+				Pollutants:            req.Pollutants,
+				TravelerProfile:       req.TravelerProfile,
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				semaphore <- struct{}{}        // Acquire
+				defer func() { <-semaphore }() // Release
+
+				rec, err := s.GetRecommendation(ctx, pairReq)
+				results <- pairResult{dest: d, origin: o, recommendation: rec, err: err}
+			}()
+		}
+	}
+
+	wg.Wait()
+	close(results)
+
+	recs := make([][]*types.TravelRecommendation, len(candidates))
+	for d := range recs {
+		recs[d] = make([]*types.TravelRecommendation, len(origins))
+	}
+	for r := range results {
+		if r.err != nil {
+			return nil, fmt.Errorf("%s to %s: %w", origins[r.origin].Name, candidates[r.dest].Name, r.err)
+		}
+		recs[r.dest][r.origin] = r.recommendation
+	}
+
+	destinations := make([]types.GroupDestination, len(recs))
+	for d, byOrigin := range recs {
+		dest := types.GroupDestination{
+			ID:          candidates[d].ID,
+			Name:        candidates[d].Name,
+			Destination: byOrigin[0].DestinationWeather,
+		}
+
+		var sum float64
+		for o, rec := range byOrigin {
+			sum += rec.Score
+			if o == 0 || rec.Score < dest.WorstScore {
+				dest.WorstScore = rec.Score
+				dest.WorstOffOrigin = origins[o].Name
+			}
+			dest.Travelers = append(dest.Travelers, types.GroupTraveler{
+				Origin:         origins[o].Name,
+				Recommendation: rec.Recommendation,
+				Score:          rec.Score,
+				Reason:         rec.Reason,
+				TempDifference: rec.TempDifference,
+			})
+		}
+		dest.AvgScore = round2(sum / float64(len(byOrigin)))
+
+		dest.Score = dest.AvgScore
+		if optimizeFor == OptimizeWorstOff {
+			dest.Score = dest.WorstScore
+		}
+		dest.Recommendation = s.scoring.verdict(dest.Score)
+		destinations[d] = dest
+	}
+
+	// Best score first; ties go to the other measure, then by name
+	sort.Slice(destinations, func(i, j int) bool {
+		a, b := destinations[i], destinations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.AvgScore+a.WorstScore != b.AvgScore+b.WorstScore {
+			return a.AvgScore+a.WorstScore > b.AvgScore+b.WorstScore
+		}
+		return a.Name < b.Name
+	})
+	for i := range destinations {
+		destinations[i].Rank = i + 1
+	}
+
+	best := destinations[0]
+	reason := fmt.Sprintf("%s has the best average score (%.1f) across %d travelers.", best.Name, best.AvgScore, len(origins))
+	if optimizeFor == OptimizeWorstOff {
+		reason = fmt.Sprintf("%s is best for the worst-off traveler (%s, score %.1f).", best.Name, best.WorstOffOrigin, best.WorstScore)
+	}
+	if best.WorstScore < s.scoring.Thresholds.Neutral {
+		reason += fmt.Sprintf(" It is still %s for travelers from %s.", s.scoring.verdict(best.WorstScore), best.WorstOffOrigin)
+	}
+
+	return &types.GroupTripResponse{
+		TravelDate:      req.TravelDate,
+		OptimizeFor:     optimizeFor,
+		BestDestination: best.Name,
+		Reason:          reason,
+		Destinations:    destinations,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/services/forecast"
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+var (
+	// historyStart is the first date the reanalysis archive covers
+	historyStart = time.Date(1940, 1, 1, 0, 0, 0, 0, time.UTC)
+	// airQualityHistoryStart is the first date global air-quality history covers;
+	// earlier dates only compare temperature
+	airQualityHistoryStart = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// fetchObservedForDate fetches observed 2PM temperature and air quality for a past date
+func (s *TravelService) fetchObservedForDate(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error) {
+	type airQualityResult struct {
+		value  types.Pollutants
+		report types.AQIReport
+		err    error
+	}
+
+	aqCh := make(chan airQualityResult, 1)
+
+	// The air-quality API serves past dates from the same endpoint as forecasts
+	go func() {
+		aq, report, err := s.fetchAirQuality(ctx, lat, long, date)
+		aqCh <- airQualityResult{value: aq, report: report, err: err}
+	}()
+
+	weather, err := s.fetchObservedTemperatureForDate(ctx, lat, long, date)
+	aqResult := <-aqCh
+
+	if err != nil {
+		return types.LocationWeather{}, err
+	}
+	if aqResult.err != nil {
+		return types.LocationWeather{}, aqResult.err
+	}
+
+	aq := aqResult.value
+	weather.PM25 = aq.PM25
+	weather.PM10 = aq.PM10
+	weather.NO2 = aq.NO2
+	weather.O3 = aq.O3
+	weather.SO2 = aq.SO2
+	weather.CO = aq.CO
+	weather.AQI = aqResult.report
+
+	return weather, nil
+}
+
+// fetchObservedTemperatureForDate fetches observed 2PM temperature for a past
+// date. The archive trails real time by about five days, so recent dates fall
+// back to the forecast API, which also serves the last few months.
+func (s *TravelService) fetchObservedTemperatureForDate(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error) {
+	q := forecast.Query{StartDate: date, EndDate: date}
+
+	hourly, err := s.provider.Historical(ctx, lat, long, q)
+	if err == nil {
+		temps := forecast.ValuesAt(hourly.Temperature, forecast.HourIndices(hourly.Time, 14))
+		if len(temps) > 0 {
+			// The archive has no UV index
+			return types.LocationWeather{Temp2PM: round2(temps[0])}, nil
+		}
+	}
+
+	temp, uv, _, ferr := s.fetchForecast(ctx, lat, long, date)
+	if ferr != nil {
+		if err != nil {
+			return types.LocationWeather{}, err
+		}
+		return types.LocationWeather{}, fmt.Errorf("no observed 2PM temperature data found")
+	}
+
+	return types.LocationWeather{
+		Temp2PM:    temp,
+		UVIndex:    uvIndex(uv),
+		UVCategory: uvCategory(uv),
+	}, nil
+}
+
+// observedConfidence rates a report built from observed data
+func observedConfidence() types.ForecastConfidence {
+	return types.ForecastConfidence{
+		Level:   ConfidenceHigh,
+		Score:   1,
+		Reasons: []string{"date is in the past, based on observed data"},
+	}
+}
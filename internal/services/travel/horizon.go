@@ -0,0 +1,116 @@
+package travel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/services/forecast"
+)
+
+// bangladeshTime is Bangladesh Standard Time, UTC+6 with no daylight saving.
+// Travel dates and forecast days are local dates in Bangladesh.
+var bangladeshTime = time.FixedZone("Asia/Dhaka", 6*60*60)
+
+// Forecast range tiers by lead time
+const (
+	RangeShort    = "short"    // up to 3 days
+	RangeMedium   = "medium"   // 4-7 days
+	RangeExtended = "extended" // beyond 7 days
+)
+
+// HorizonConfig sets how far ahead forecasts are used. Beyond ForecastDays the
+// answer comes from climate normals; between AirQualityDays and ForecastDays
+// only temperature is compared.
+type HorizonConfig struct {
+	ForecastDays   int
+	AirQualityDays int
+}
+
+// DefaultHorizonConfig uses the full Open-Meteo weather horizon and the
+// 5-day global air-quality forecast
+var DefaultHorizonConfig = HorizonConfig{
+	ForecastDays:   forecast.MaxForecastDays,
+	AirQualityDays: 5,
+}
+
+// Validate checks the horizons against the provider limits
+func (c HorizonConfig) Validate() error {
+	if c.ForecastDays < 1 || c.ForecastDays > forecast.MaxForecastDays {
+		return fmt.Errorf("forecast horizon must be between 1 and %d days", forecast.MaxForecastDays)
+	}
+	if c.AirQualityDays < 1 || c.AirQualityDays > forecast.MaxAirQualityDays {
+		return fmt.Errorf("air-quality horizon must be between 1 and %d days", forecast.MaxAirQualityDays)
+	}
+	if c.AirQualityDays > c.ForecastDays {
+		return fmt.Errorf("air-quality horizon must not exceed the forecast horizon")
+	}
+	return nil
+}
+
+// ParseHorizonConfig reads the horizons in days, starting from
+// DefaultHorizonConfig. Empty arguments keep the defaults.
+func ParseHorizonConfig(forecastDays, airQualityDays string) (HorizonConfig, error) {
+	config := DefaultHorizonConfig
+
+	fields := []struct {
+		name   string
+		text   string
+		target *int
+	}{
+		{"forecast horizon", forecastDays, &config.ForecastDays},
+		{"air-quality horizon", airQualityDays, &config.AirQualityDays},
+	}
+
+	for _, f := range fields {
+		text := strings.TrimSpace(f.text)
+		if text == "" {
+			continue
+		}
+		days, err := strconv.Atoi(text)
+		if err != nil {
+			return HorizonConfig{}, fmt.Errorf("%s %q must be a whole number of days", f.name, f.text)
+		}
+		*f.target = days
+	}
+
+	if err := config.Validate(); err != nil {
+		return HorizonConfig{}, err
+	}
+	return config, nil
+}
+
+// ForecastWindow returns the forecast and air-quality date ranges fetched
+// for every location, from today through each horizon
+func (s *TravelService) ForecastWindow() (forecast.Query, forecast.Query) {
+	from := today()
+	forecastQuery := forecast.Query{
+		StartDate: from.Format("2006-01-02"),
+		EndDate:   from.AddDate(0, 0, s.horizon.ForecastDays-1).Format("2006-01-02"),
+	}
+	airQualityQuery := forecast.Query{
+		StartDate: from.Format("2006-01-02"),
+		EndDate:   from.AddDate(0, 0, s.horizon.AirQualityDays-1).Format("2006-01-02"),
+	}
+	return forecastQuery, airQualityQuery
+}
+
+// forecastRange classifies the lead time into a range tier
+func forecastRange(leadDays int) string {
+	switch {
+	case leadDays <= 3:
+		return RangeShort
+	case leadDays <= 7:
+		return RangeMedium
+	default:
+		return RangeExtended
+	}
+}
+
+// today returns the current date in Bangladesh at midnight UTC, so it lines up
+// with dates parsed from YYYY-MM-DD
+func today() time.Time {
+	year, month, day := time.Now().In(bangladeshTime).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
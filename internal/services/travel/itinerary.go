@@ -0,0 +1,282 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+const (
+	// maxItineraryStops bounds the stops in an itinerary; every order of the
+	// stops is tried when looking for a better one
+	maxItineraryStops = 5
+	// reorderMinGain is how many points a reordering must add to the average
+	// stop score to be suggested when it doesn't avoid any sharp legs
+	reorderMinGain = 1.0
+)
+
+// locationDay identifies a place on a date; the current location has no name
+type locationDay struct {
+	name string
+	date string
+}
+
+// itinerary is the evaluation of one order of stops
+type itinerary struct {
+	stops     []types.ItineraryStopReport
+	legs      []types.ItineraryLeg
+	avgScore  float64
+	sharpLegs int
+}
+
+// GetItinerary evaluates an ordered list of stops. Each stop is compared with
+// the current location on the stop's date, and each leg with the place the
+// traveler arrives from. A leg worsens sharply when arriving would be strongly
+// discouraged. Every other order of the stops over the same dates is tried,
+// and a better one is suggested.
+func (s *TravelService) GetItinerary(ctx context.Context, req types.ItineraryRequest) (*types.ItineraryResponse, error) {
+	if len(req.Stops) == 0 || len(req.Stops) > maxItineraryStops {
+		return nil, fmt.Errorf("itineraries must have between 1 and %d stops", maxItineraryStops)
+	}
+
+	// Stops can name districts by ID or English or Bangla name
+	stops := make([]types.ItineraryStop, len(req.Stops))
+	plans := make(map[string]datePlan)
+	var districts []types.District
+	seen := make(map[string]bool)
+	for i, stop := range req.Stops {
+		district, err := s.resolveDistrict(stop.DistrictName)
+		if err != nil {
+			return nil, fmt.Errorf("stop %d: %w", i+1, err)
+		}
+		stops[i] = types.ItineraryStop{DistrictName: district.Name, Date: stop.Date}
+		if !seen[district.Name] {
+			seen[district.Name] = true
+			districts = append(districts, district)
+		}
+
+		plan, err := s.planDate(stop.Date)
+		if err != nil {
+			return nil, fmt.Errorf("stop %d: %w", i+1, err)
+		}
+		plans[stop.Date] = plan
+
+		// Validated dates compare correctly as strings
+		if i > 0 && stop.Date < req.Stops[i-1].Date {
+			return nil, fmt.Errorf("stops must be in date order")
+		}
+	}
+
+	origin, err := s.locateOrigin(req.CurrentLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	pollutants, err := pollutant.Normalize(req.Pollutants)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := LookupProfile(req.TravelerProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	weather, err := s.fetchItineraryWeather(ctx, req.CurrentLocation, districts, plans)
+	if err != nil {
+		return nil, err
+	}
+
+	comparisonOn := func(date string, plan datePlan) comparison {
+		return comparison{
+			date:              date,
+			basis:             plan.basis,
+			missingAirQuality: plan.missingAirQuality,
+			pollutants:        pollutants,
+			profile:           profile,
+			confidence:        plan.confidence(nil, nil, nil),
+		}
+	}
+
+	// evaluate scores the stops' districts visited in the given order on the
+	// original dates
+	evaluate := func(order []int) itinerary {
+		var it itinerary
+		from := weather[locationDay{date: stops[0].Date}]
+		fromPlan := plans[stops[0].Date]
+
+		for slot, i := range order {
+			date := stops[slot].Date
+			name := stops[i].DistrictName
+			plan := plans[date]
+			dest := weather[locationDay{name: name, date: date}]
+
+			stop := s.compare(weather[locationDay{date: date}], dest, comparisonOn(date, plan))
+			it.stops = append(it.stops, types.ItineraryStopReport{
+				DistrictName:    name,
+				Date:            date,
+				Basis:           stop.Basis,
+				TemperatureOnly: stop.TemperatureOnly,
+				Recommendation:  stop.Recommendation,
+				Score:           stop.Score,
+				Reason:          stop.Reason,
+				Weather:         dest,
+			})
+			it.avgScore += stop.Score
+
+			// A leg can only compare air quality when both ends have it
+			c := comparisonOn(date, plan)
+			if c.missingAirQuality == "" {
+				c.missingAirQuality = fromPlan.missingAirQuality
+			}
+			leg := s.compare(from, dest, c)
+			sharp := leg.Score < s.scoring.Thresholds.NotRecommended
+			if sharp {
+				it.sharpLegs++
+			}
+			it.legs = append(it.legs, types.ItineraryLeg{
+				From:                 from.Name,
+				To:                   name,
+				Date:                 date,
+				Recommendation:       leg.Recommendation,
+				Score:                leg.Score,
+				Reason:               leg.Reason,
+				TempDifference:       leg.TempDifference,
+				PollutantDifferences: leg.PollutantDifferences,
+				SharpWorsening:       sharp,
+			})
+
+			from, fromPlan = dest, plan
+		}
+
+		it.avgScore = round2(it.avgScore / float64(len(order)))
+		return it
+	}
+
+	order := make([]int, len(stops))
+	for i := range order {
+		order[i] = i
+	}
+	current := evaluate(order)
+
+	// Fewest sharp legs first, then the best average stop score
+	best, bestOrder := current, order
+	for _, perm := range permutations(len(stops)) {
+		it := evaluate(perm)
+		if it.sharpLegs < best.sharpLegs || (it.sharpLegs == best.sharpLegs && it.avgScore > best.avgScore) {
+			best, bestOrder = it, perm
+		}
+	}
+
+	resp := &types.ItineraryResponse{
+		CurrentWeather: weather[locationDay{date: stops[0].Date}],
+		Origin:         origin,
+		AvgScore:       current.avgScore,
+		SharpLegs:      current.sharpLegs,
+		Stops:          current.stops,
+		Legs:           current.legs,
+	}
+
+	avoidsSharpLegs := best.sharpLegs < current.sharpLegs
+	if avoidsSharpLegs || best.avgScore-current.avgScore >= reorderMinGain {
+		suggestion := &types.ItinerarySuggestion{
+			AvgScore:  best.avgScore,
+			SharpLegs: best.sharpLegs,
+		}
+		for slot, i := range bestOrder {
+			suggestion.Stops = append(suggestion.Stops, types.ItineraryStop{
+				DistrictName: stops[i].DistrictName,
+				Date:         stops[slot].Date,
+			})
+		}
+		if avoidsSharpLegs {
+			suggestion.Reason = fmt.Sprintf("Visiting in this order avoids %d leg(s) where conditions worsen sharply.", current.sharpLegs-best.sharpLegs)
+		} else {
+			suggestion.Reason = fmt.Sprintf("Visiting in this order raises the average stop score from %.1f to %.1f.", current.avgScore, best.avgScore)
+		}
+		resp.SuggestedOrder = suggestion
+	}
+
+	return resp, nil
+}
+
+// fetchItineraryWeather fetches the current location and every stop district
+// on every stop date, so any order of the stops can be evaluated
+func (s *TravelService) fetchItineraryWeather(ctx context.Context, origin types.Location, districts []types.District, plans map[string]datePlan) (map[locationDay]types.LocationWeather, error) {
+	type weatherResult struct {
+		key     locationDay
+		weather types.LocationWeather
+		err     error
+	}
+
+	originName := origin.Name
+	if originName == "" {
+		originName = "Current Location"
+	}
+
+	results := make(chan weatherResult, (len(districts)+1)*len(plans))
+	var wg sync.WaitGroup
+
+	// Use a semaphore to limit concurrent requests (avoid rate limiting)
+	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
+
+	fetch := func(key locationDay, name string, lat, long float64, plan datePlan) {
+		defer wg.Done()
+
+		semaphore <- struct{}{}        // Acquire
+		defer func() { <-semaphore }() // Release
+
+		weather, err := plan.fetchWeather(ctx, lat, long, key.date)
+		weather.Name = name
+		weather.Debug = nil
+		results <- weatherResult{key: key, weather: weather, err: err}
+	}
+
+	for date, plan := range plans {
+		wg.Add(1)
+		go fetch(locationDay{date: date}, originName, origin.Lat, origin.Long, plan)
+
+		for _, d := range districts {
+			wg.Add(1)
+			go fetch(locationDay{name: d.Name, date: date}, d.Name, d.Lat, d.Long, plan)
+		}
+	}
+
+	wg.Wait()
+	close(results)
+
+	weather := make(map[locationDay]types.LocationWeather, cap(results))
+	for r := range results {
+		if r.err != nil {
+			if r.key.name == "" {
+				return nil, fmt.Errorf("failed to fetch current location weather on %s: %w", r.key.date, r.err)
+			}
+			return nil, fmt.Errorf("failed to fetch %s weather on %s: %w", r.key.name, r.key.date, r.err)
+		}
+		weather[r.key] = r.weather
+	}
+	return weather, nil
+}
+
+// permutations lists every order of 0..n-1
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+
+	var perms [][]int
+	for _, perm := range permutations(n - 1) {
+		// Insert n-1 at every position of each shorter order
+		for pos := 0; pos <= len(perm); pos++ {
+			p := make([]int, 0, n)
+			p = append(p, perm[:pos]...)
+			p = append(p, n-1)
+			p = append(p, perm[pos:]...)
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
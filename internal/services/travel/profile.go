@@ -0,0 +1,146 @@
+package travel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// Traveler profile names
+const (
+	ProfileGeneral       = "general"
+	ProfileElderly       = "elderly"
+	ProfileChild         = "child"
+	ProfileAsthma        = "asthma"
+	ProfileHeatSensitive = "heat-sensitive"
+)
+
+// TravelerProfile adjusts recommendation thresholds for travelers who are
+// more vulnerable to heat or air pollution. Zero limits are not enforced.
+type TravelerProfile struct {
+	Name        string
+	Description string
+	// MaxTemp2PM is the highest acceptable 2PM temperature at the destination (°C)
+	MaxTemp2PM float64
+	// MaxPM25 is the highest acceptable PM2.5 at the destination (µg/m³)
+	MaxPM25 float64
+	// GuidelineFactor scales the WHO guideline values used for health advisories
+	GuidelineFactor float64
+	// HeatWeight and AirWeight multiply the temperature and air-quality scoring
+	// weights; zero means unchanged
+	HeatWeight float64
+	AirWeight  float64
+}
+
+var profiles = map[string]TravelerProfile{
+	ProfileGeneral: {
+		Name:            ProfileGeneral,
+		Description:     "Healthy adult with no special sensitivities",
+		GuidelineFactor: 1,
+	},
+	ProfileElderly: {
+		Name:            ProfileElderly,
+		Description:     "Older traveler with reduced tolerance for heat",
+		MaxTemp2PM:      32,
+		MaxPM25:         37.5,
+		GuidelineFactor: 0.75,
+		HeatWeight:      1.5,
+	},
+	ProfileChild: {
+		Name:            ProfileChild,
+		Description:     "Young child, more exposed to heat and pollution per body weight",
+		MaxTemp2PM:      33,
+		MaxPM25:         37.5,
+		GuidelineFactor: 0.75,
+		HeatWeight:      1.25,
+		AirWeight:       1.25,
+	},
+	ProfileAsthma: {
+		Name:            ProfileAsthma,
+		Description:     "Traveler with asthma or another respiratory condition",
+		MaxPM25:         25,
+		GuidelineFactor: 0.5,
+		AirWeight:       2,
+	},
+	ProfileHeatSensitive: {
+		Name:            ProfileHeatSensitive,
+		Description:     "Traveler prone to heat exhaustion",
+		MaxTemp2PM:      30,
+		GuidelineFactor: 1,
+		HeatWeight:      2,
+	},
+}
+
+// LookupProfile returns the named profile, defaulting to general when empty
+func LookupProfile(name string) (TravelerProfile, error) {
+	if name == "" {
+		return profiles[ProfileGeneral], nil
+	}
+
+	p, ok := profiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return TravelerProfile{}, fmt.Errorf("unknown traveler profile %q, supported: %s", name, strings.Join(names, ", "))
+	}
+
+	return p, nil
+}
+
+// applyRules checks the destination against the profile's limits and returns
+// the evaluated rules. Air-quality limits are skipped without air-quality data.
+// A profile without rules gets an empty list, so it serializes as [] not null.
+func (p TravelerProfile) applyRules(dest types.LocationWeather, hasAirQuality bool) []types.ProfileRule {
+	rules := []types.ProfileRule{}
+
+	if p.MaxTemp2PM > 0 {
+		rules = append(rules, types.ProfileRule{
+			Rule:   fmt.Sprintf("destination 2PM temperature must not exceed %.1f°C", p.MaxTemp2PM),
+			Passed: dest.Temp2PM <= p.MaxTemp2PM,
+		})
+	}
+
+	if p.MaxPM25 > 0 && hasAirQuality {
+		rules = append(rules, types.ProfileRule{
+			Rule:   fmt.Sprintf("destination PM2.5 must not exceed %.1f µg/m³", p.MaxPM25),
+			Passed: dest.PM25 <= p.MaxPM25,
+		})
+	}
+
+	if p.GuidelineFactor > 0 && p.GuidelineFactor != 1 {
+		rules = append(rules, types.ProfileRule{
+			Rule:   fmt.Sprintf("health advisory uses %.0f%% of WHO guideline values", p.GuidelineFactor*100),
+			Passed: true,
+		})
+	}
+
+	if p.HeatWeight > 0 && p.HeatWeight != 1 {
+		rules = append(rules, types.ProfileRule{
+			Rule:   fmt.Sprintf("temperature counts %.2gx in the score", p.HeatWeight),
+			Passed: true,
+		})
+	}
+
+	if p.AirWeight > 0 && p.AirWeight != 1 {
+		rules = append(rules, types.ProfileRule{
+			Rule:   fmt.Sprintf("air quality counts %.2gx in the score", p.AirWeight),
+			Passed: true,
+		})
+	}
+
+	return rules
+}
+
+// report echoes the profile with the rules that were applied
+func (p TravelerProfile) report(rules []types.ProfileRule) types.TravelerProfileReport {
+	return types.TravelerProfileReport{
+		Name:         p.Name,
+		Description:  p.Description,
+		AppliedRules: rules,
+	}
+}
@@ -0,0 +1,244 @@
+package travel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geodata"
+)
+
+// destination is a resolved trip destination. For a district it is the
+// district itself. For an upazila, Name, BnName and the coordinates are the
+// upazila's while ID, DivisionID and DivisionName are its parent district's.
+// Raw coordinates only have a name and coordinates.
+type destination struct {
+	types.District
+	// DistrictName names an upazila's district; empty otherwise
+	DistrictName string
+}
+
+// resolveDestination finds the destination from whichever identifiers a
+// request sets: a district ID, an English or Bangla district name, an upazila,
+// or raw coordinates. District identifiers must all match the same district,
+// which an upazila must then be in, and coordinates can't be combined with
+// them.
+func (s *TravelService) resolveDestination(id, name, upazila string, location *types.Location) (destination, error) {
+	id, name, upazila = strings.TrimSpace(id), strings.TrimSpace(name), strings.TrimSpace(upazila)
+
+	if location != nil {
+		if id != "" || name != "" || upazila != "" {
+			return destination{}, fmt.Errorf("set either destination_location or a destination district or upazila, not both")
+		}
+		return s.coordinateDestination(*location)
+	}
+
+	if upazila != "" {
+		return s.resolveUpazila(upazila, id, name)
+	}
+
+	if id == "" && name == "" {
+		return destination{}, fmt.Errorf("destination is required: set destination_district, destination_district_id, destination_upazila or destination_location")
+	}
+
+	var (
+		byID, byName types.District
+		failures     []string
+	)
+	if id != "" {
+		d, ok := s.districts.ByID(id)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("destination_district_id %q matches no district", id))
+		}
+		byID = d
+	}
+	if name != "" {
+		d, ok := s.districts.ByName(name)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("destination_district %q matches no English or Bangla district name%s", name, s.didYouMean(name)))
+		}
+		byName = d
+	}
+	if len(failures) > 0 {
+		return destination{}, fmt.Errorf("destination district not found: %s", strings.Join(failures, "; "))
+	}
+
+	switch {
+	case id == "":
+		return destination{District: byName}, nil
+	case name == "":
+		return destination{District: byID}, nil
+	case byID.ID != byName.ID:
+		return destination{}, fmt.Errorf("destination_district_id %q (%s) and destination_district %q (%s) are different districts", id, byID.Name, name, byName.Name)
+	default:
+		return destination{District: byID}, nil
+	}
+}
+
+// resolveUpazila finds an upazila destination, within the district the other
+// identifiers name when they're set. Upazila names repeat across districts,
+// so an ambiguous name needs a district.
+func (s *TravelService) resolveUpazila(upazila, districtID, districtName string) (destination, error) {
+	matches := s.districts.FindUpazilas(upazila)
+
+	if districtID != "" || districtName != "" {
+		district, err := s.resolveDestination(districtID, districtName, "", nil)
+		if err != nil {
+			return destination{}, err
+		}
+
+		if len(s.districts.UpazilasIn(district.ID)) == 0 {
+			return destination{}, s.noUpazilaData(district.District)
+		}
+
+		var inDistrict []types.Upazila
+		for _, u := range matches {
+			if u.DistrictID == district.ID {
+				inDistrict = append(inDistrict, u)
+			}
+		}
+		if len(inDistrict) == 0 {
+			return destination{}, fmt.Errorf("destination upazila not found: %s has no upazila %q", district.Name, upazila)
+		}
+		matches = inDistrict
+	}
+
+	switch len(matches) {
+	case 0:
+		return destination{}, fmt.Errorf("destination upazila not found: %s (%s)", upazila, s.upazilaCoverage())
+	case 1:
+		u := matches[0]
+		parent, _ := s.districts.ByID(u.DistrictID)
+		return destination{
+			District: types.District{
+				ID:           u.DistrictID,
+				DivisionID:   parent.DivisionID,
+				DivisionName: parent.DivisionName,
+				Name:         u.Name,
+				BnName:       u.BnName,
+				Lat:          u.Lat,
+				Long:         u.Long,
+			},
+			DistrictName: parent.Name,
+		}, nil
+	default:
+		candidates := make([]string, len(matches))
+		for i, u := range matches {
+			candidates[i] = fmt.Sprintf("%s (%s)", u.Name, u.DistrictName)
+		}
+		return destination{}, fmt.Errorf("destination_upazila %q is in several districts: %s; set destination_district too", upazila, strings.Join(candidates, ", "))
+	}
+}
+
+// noUpazilaData reports that the upazila data doesn't cover a district
+func (s *TravelService) noUpazilaData(district types.District) error {
+	return fmt.Errorf("no upazila data for %s (%s)", district.Name, s.upazilaCoverage())
+}
+
+// upazilaCoverage names the districts the upazila data covers
+func (s *TravelService) upazilaCoverage() string {
+	covered := s.districts.UpazilaDistricts()
+	if len(covered) == 0 {
+		return "no upazila data is loaded"
+	}
+
+	names := make([]string, len(covered))
+	for i, d := range covered {
+		names[i] = d.Name
+	}
+	return "upazilas are only available in " + strings.Join(names, ", ")
+}
+
+// resolveDistrict finds a district listed by ID or English or Bangla name
+func (s *TravelService) resolveDistrict(identifier string) (types.District, error) {
+	d, ok := s.districts.Lookup(identifier)
+	if !ok {
+		return types.District{}, fmt.Errorf("destination district not found: %s%s", identifier, s.didYouMean(identifier))
+	}
+	return d, nil
+}
+
+// didYouMean suggests districts close to a name that matched none, formatted
+// to be appended to an error
+func (s *TravelService) didYouMean(name string) string {
+	suggestions := s.districts.Suggest(name, 3)
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, ", "))
+}
+
+// maxOriginDistanceKm is how far the current location or destination
+// coordinates may be from the nearest district center before they are
+// rejected as outside the supported region. Closer origins outside Bangladesh
+// are compared anyway, with a warning. It only applies when neither district
+// boundaries nor the country outline are loaded.
+const maxOriginDistanceKm = 250.0
+
+// boundaryToleranceKm is how far outside the district boundaries or the
+// country outline the current location or destination coordinates may be and
+// still be compared. It allows for GPS error, which is tens of metres on a
+// phone; the bundled outline is generalized by about 20 m.
+const boundaryToleranceKm = 0.1
+
+// locateOrigin reverse-geocodes the current location to its district and
+// upazila, and rejects origins outside the supported region. It returns nil
+// when no districts are loaded.
+func (s *TravelService) locateOrigin(location types.Location) (*types.ReverseGeocode, error) {
+	if !validCoordinates(location) {
+		return nil, fmt.Errorf("current_location must have lat within ±90 and long within ±180")
+	}
+
+	origin := s.districts.ReverseGeocode(location.Lat, location.Long)
+	if origin.District == nil {
+		return nil, nil
+	}
+	if err := checkRegion("current_location", location, origin); err != nil {
+		return nil, err
+	}
+	return &origin, nil
+}
+
+// coordinateDestination turns raw coordinates into an unnamed destination,
+// rejecting coordinates outside the supported region like origins
+func (s *TravelService) coordinateDestination(location types.Location) (destination, error) {
+	if !validCoordinates(location) {
+		return destination{}, fmt.Errorf("destination_location must have lat within ±90 and long within ±180")
+	}
+
+	if located := s.districts.ReverseGeocode(location.Lat, location.Long); located.District != nil {
+		if err := checkRegion("destination_location", location, located); err != nil {
+			return destination{}, err
+		}
+	}
+
+	name := location.Name
+	if name == "" {
+		name = fmt.Sprintf("Destination (%.4f, %.4f)", location.Lat, location.Long)
+	}
+	return destination{District: types.District{Name: name, Lat: location.Lat, Long: location.Long}}, nil
+}
+
+// checkRegion rejects a located point too far outside Bangladesh to compare.
+// field names the request field in the error.
+func checkRegion(field string, location types.Location, located types.ReverseGeocode) error {
+	switch {
+	case located.InBangladesh:
+	case located.Method == geodata.MethodBoundary && located.BoundaryDistanceKm > boundaryToleranceKm:
+		return fmt.Errorf("%s (%.4f, %.4f) is outside the supported region: it is %.1f km from the nearest district, %s",
+			field, location.Lat, location.Long, located.BoundaryDistanceKm, located.District.Name)
+	case located.BoundaryDistanceKm > boundaryToleranceKm:
+		return fmt.Errorf("%s (%.4f, %.4f) is outside Bangladesh: it is %.1f km from the border",
+			field, location.Lat, location.Long, located.BoundaryDistanceKm)
+	case located.Method == geodata.MethodNearestCenter && located.District.DistanceKm > maxOriginDistanceKm:
+		return fmt.Errorf("%s (%.4f, %.4f) is outside Bangladesh: the nearest district, %s, is %.0f km away",
+			field, location.Lat, location.Long, located.District.Name, located.District.DistanceKm)
+	}
+	return nil
+}
+
+// validCoordinates reports whether a location has lat within ±90 and long
+// within ±180
+func validCoordinates(location types.Location) bool {
+	return location.Lat >= -90 && location.Lat <= 90 && location.Long >= -180 && location.Long <= 180
+}
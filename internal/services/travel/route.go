@@ -0,0 +1,155 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geo"
+)
+
+const (
+	// defaultRouteSamples is how many points are sampled along a route,
+	// including the origin and destination
+	defaultRouteSamples = 6
+	// maxRouteSamples bounds the extra fetches a route costs
+	maxRouteSamples = 12
+	// maxRouteWaypoints bounds a supplied polyline
+	maxRouteWaypoints = 50
+)
+
+// routePoints validates route options and samples points from the origin to
+// the destination, along the great circle or through the supplied waypoints
+func routePoints(origin types.Location, destination types.District, route *types.RouteRequest) ([]geo.PathPoint, error) {
+	samples := route.Samples
+	if samples == 0 {
+		samples = defaultRouteSamples
+	}
+	if samples < 2 || samples > maxRouteSamples {
+		return nil, fmt.Errorf("route samples must be between 2 and %d", maxRouteSamples)
+	}
+	if len(route.Polyline) > maxRouteWaypoints {
+		return nil, fmt.Errorf("route polyline can have at most %d waypoints", maxRouteWaypoints)
+	}
+	for i, waypoint := range route.Polyline {
+		if !validCoordinates(waypoint) {
+			return nil, fmt.Errorf("route polyline waypoint %d must have lat within ±90 and long within ±180", i+1)
+		}
+	}
+
+	path := make([]types.Location, 0, len(route.Polyline)+2)
+	path = append(path, origin)
+	path = append(path, route.Polyline...)
+	path = append(path, types.Location{Lat: destination.Lat, Long: destination.Long})
+
+	return geo.Sample(path, samples), nil
+}
+
+// fetchRouteWeather fetches the weather at the points between the origin and
+// the destination, whose weather is fetched with the recommendation itself
+func (s *TravelService) fetchRouteWeather(ctx context.Context, plan datePlan, date string, points []geo.PathPoint) ([]types.LocationWeather, error) {
+	type pointResult struct {
+		index   int
+		weather types.LocationWeather
+		err     error
+	}
+
+	interior := points[1 : len(points)-1]
+	results := make(chan pointResult, len(interior))
+	var wg sync.WaitGroup
+
+	// Use a semaphore to limit concurrent requests (avoid rate limiting)
+	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
+
+	for i, p := range interior {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			weather, err := plan.fetchWeather(ctx, p.Lat, p.Long, date)
+			weather.Name = fmt.Sprintf("Route km %.0f", p.DistanceKm)
+			weather.Debug = nil
+			results <- pointResult{index: i, weather: weather, err: err}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	weather := make([]types.LocationWeather, len(interior))
+	for r := range results {
+		if r.err != nil {
+			return nil, fmt.Errorf("route point at %.0f km: %w", interior[r.index].DistanceKm, r.err)
+		}
+		weather[r.index] = r.weather
+	}
+	return weather, nil
+}
+
+// routeReport scores every route point like a destination and finds the
+// worst segment between neighbouring points. weather holds one entry per
+// point, origin first.
+func (s *TravelService) routeReport(points []geo.PathPoint, weather []types.LocationWeather, c comparison) *types.RouteReport {
+	report := &types.RouteReport{
+		DistanceKm: round2(points[len(points)-1].DistanceKm),
+		Points:     make([]types.RoutePoint, len(points)),
+	}
+	for i, p := range points {
+		report.Points[i] = types.RoutePoint{
+			Lat:        round2(p.Lat),
+			Long:       round2(p.Long),
+			DistanceKm: round2(p.DistanceKm),
+			Temp2PM:    weather[i].Temp2PM,
+			PM25:       weather[i].PM25,
+			UVIndex:    weather[i].UVIndex,
+			Score:      s.compare(weather[0], weather[i], c).Score,
+		}
+	}
+
+	for i := 1; i < len(report.Points); i++ {
+		from, to := report.Points[i-1], report.Points[i]
+		score := math.Min(from.Score, to.Score)
+		maxTemp := math.Max(from.Temp2PM, to.Temp2PM)
+		segment := types.RouteSegment{
+			FromKm:         from.DistanceKm,
+			ToKm:           to.DistanceKm,
+			Recommendation: s.scoring.verdict(score),
+			Score:          score,
+			MaxTemp2PM:     maxTemp,
+			MaxPM25:        math.Max(from.PM25, to.PM25),
+			ExtremeHeat:    maxTemp >= extremeHeatTemp2PM,
+		}
+		if i == 1 || worseSegment(segment, *report.WorstSegment) {
+			report.WorstSegment = &segment
+		}
+	}
+
+	return report
+}
+
+// unavailableRoute reports a route whose weather couldn't be fetched. The
+// recommendation itself still stands.
+func unavailableRoute(points []geo.PathPoint, err error) *types.RouteReport {
+	return &types.RouteReport{
+		DistanceKm:  round2(points[len(points)-1].DistanceKm),
+		Unavailable: true,
+		Error:       err.Error(),
+	}
+}
+
+// worseSegment reports whether a is worse than b. Severe heat is worst
+// whatever the origin is like; otherwise the lower score is worse.
+func worseSegment(a, b types.RouteSegment) bool {
+	if a.ExtremeHeat != b.ExtremeHeat {
+		return a.ExtremeHeat
+	}
+	if a.ExtremeHeat && a.MaxTemp2PM != b.MaxTemp2PM {
+		return a.MaxTemp2PM > b.MaxTemp2PM
+	}
+	return a.Score < b.Score
+}
@@ -0,0 +1,241 @@
+package travel
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// Graded verdicts, best to worst
+const (
+	VerdictStronglyRecommended = "Strongly Recommended"
+	VerdictRecommended         = "Recommended"
+	VerdictNeutral             = "Neutral"
+	VerdictNotRecommended      = "Not Recommended"
+	VerdictStronglyDiscouraged = "Strongly Discouraged"
+)
+
+// Scoring factor names
+const (
+	FactorTemperature = "temperature"
+	FactorAirQuality  = "air_quality"
+	FactorUVIndex     = "uv_index"
+)
+
+// VerdictThresholds are the minimum scores (0-100) for each verdict.
+// Scores below NotRecommended are strongly discouraged.
+type VerdictThresholds struct {
+	StronglyRecommended float64
+	Recommended         float64
+	Neutral             float64
+	NotRecommended      float64
+}
+
+// ScoringConfig controls how differences between origin and destination turn
+// into a 0-100 score. Each factor's difference is divided by its scale and
+// clamped to [-1, 1], so a difference of one scale counts fully.
+type ScoringConfig struct {
+	TempScale float64 // °C
+	AirScale  float64 // PM2.5-equivalent µg/m³
+	UVScale   float64 // UV index points
+
+	TempWeight float64
+	AirWeight  float64
+	UVWeight   float64
+
+	Thresholds VerdictThresholds
+}
+
+// DefaultScoringConfig weighs temperature and air quality equally, with UV as
+// a minor factor
+var DefaultScoringConfig = ScoringConfig{
+	TempScale:  5,
+	AirScale:   25,
+	UVScale:    3,
+	TempWeight: 1,
+	AirWeight:  1,
+	UVWeight:   0.25,
+	Thresholds: VerdictThresholds{
+		StronglyRecommended: 80,
+		Recommended:         60,
+		Neutral:             40,
+		NotRecommended:      20,
+	},
+}
+
+// Validate checks that scales are positive and thresholds are ordered
+func (c ScoringConfig) Validate() error {
+	if c.TempScale <= 0 || c.AirScale <= 0 || c.UVScale <= 0 {
+		return fmt.Errorf("scoring scales must be positive")
+	}
+	if c.TempWeight < 0 || c.AirWeight < 0 || c.UVWeight < 0 || c.TempWeight+c.AirWeight+c.UVWeight == 0 {
+		return fmt.Errorf("scoring weights must be non-negative and not all zero")
+	}
+
+	t := c.Thresholds
+	if !(t.StronglyRecommended > t.Recommended && t.Recommended > t.Neutral && t.Neutral > t.NotRecommended) {
+		return fmt.Errorf("verdict thresholds must be strictly decreasing")
+	}
+	if t.StronglyRecommended > 100 || t.NotRecommended < 0 {
+		return fmt.Errorf("verdict thresholds must be within 0-100")
+	}
+
+	return nil
+}
+
+// Verdict threshold keys for ParseScoringConfig
+const (
+	thresholdStronglyRecommended = "strongly_recommended"
+	thresholdRecommended         = "recommended"
+	thresholdNeutral             = "neutral"
+	thresholdNotRecommended      = "not_recommended"
+)
+
+// ParseScoringConfig reads a scoring configuration from its text form,
+// starting from DefaultScoringConfig. Each argument is optional
+// comma-separated key=value pairs: weights and scales are keyed by factor
+// (temperature, air_quality, uv_index), e.g. "temperature=2,uv_index=0", and
+// thresholds by verdict (strongly_recommended, recommended, neutral,
+// not_recommended), e.g. "strongly_recommended=85". Unknown keys are errors.
+func ParseScoringConfig(weights, scales, thresholds string) (ScoringConfig, error) {
+	config := DefaultScoringConfig
+
+	fields := []struct {
+		name   string
+		text   string
+		values map[string]*float64
+	}{
+		{"scoring weight", weights, map[string]*float64{
+			FactorTemperature: &config.TempWeight,
+			FactorAirQuality:  &config.AirWeight,
+			FactorUVIndex:     &config.UVWeight,
+		}},
+		{"scoring scale", scales, map[string]*float64{
+			FactorTemperature: &config.TempScale,
+			FactorAirQuality:  &config.AirScale,
+			FactorUVIndex:     &config.UVScale,
+		}},
+		{"verdict threshold", thresholds, map[string]*float64{
+			thresholdStronglyRecommended: &config.Thresholds.StronglyRecommended,
+			thresholdRecommended:         &config.Thresholds.Recommended,
+			thresholdNeutral:             &config.Thresholds.Neutral,
+			thresholdNotRecommended:      &config.Thresholds.NotRecommended,
+		}},
+	}
+
+	for _, f := range fields {
+		for _, pair := range strings.Split(f.text, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return ScoringConfig{}, fmt.Errorf("%s %q must be key=value", f.name, pair)
+			}
+			target, ok := f.values[strings.TrimSpace(key)]
+			if !ok {
+				return ScoringConfig{}, fmt.Errorf("%s %q: unknown key %q", f.name, pair, strings.TrimSpace(key))
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return ScoringConfig{}, fmt.Errorf("%s %q: %w", f.name, pair, err)
+			}
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return ScoringConfig{}, fmt.Errorf("%s %q must be a finite number", f.name, pair)
+			}
+			*target = v
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return ScoringConfig{}, err
+	}
+	return config, nil
+}
+
+// verdict maps a score onto a graded verdict
+func (c ScoringConfig) verdict(score float64) string {
+	t := c.Thresholds
+	switch {
+	case score >= t.StronglyRecommended:
+		return VerdictStronglyRecommended
+	case score >= t.Recommended:
+		return VerdictRecommended
+	case score >= t.Neutral:
+		return VerdictNeutral
+	case score >= t.NotRecommended:
+		return VerdictNotRecommended
+	default:
+		return VerdictStronglyDiscouraged
+	}
+}
+
+// factorDifferences are origin-minus-destination differences for scoring.
+// Positive differences mean the destination is better.
+type factorDifferences struct {
+	temp       float64 // °C
+	airQuality float64 // PM2.5-equivalent µg/m³
+	uv         float64
+
+	// Factors that could not be compared are left out of the score
+	withoutAirQuality bool
+	withoutUV         bool
+}
+
+// score turns the differences into a 0-100 score and the points each factor
+// contributed relative to the neutral 50. Factors that were not compared are
+// left out, so the remaining weights are renormalized and the full range stays
+// reachable. Profile multipliers are applied on top of the configured weights.
+func (c ScoringConfig) score(d factorDifferences, profile TravelerProfile) (float64, []types.FactorContribution) {
+	type factor struct {
+		name       string
+		difference float64
+		scale      float64
+		weight     float64
+	}
+	factors := []factor{{FactorTemperature, d.temp, c.TempScale, c.TempWeight * multiplier(profile.HeatWeight)}}
+	if !d.withoutAirQuality {
+		factors = append(factors, factor{FactorAirQuality, d.airQuality, c.AirScale, c.AirWeight * multiplier(profile.AirWeight)})
+	}
+	if !d.withoutUV {
+		factors = append(factors, factor{FactorUVIndex, d.uv, c.UVScale, c.UVWeight})
+	}
+
+	var totalWeight float64
+	for _, f := range factors {
+		totalWeight += f.weight
+	}
+
+	score := 50.0
+	contributions := make([]types.FactorContribution, 0, len(factors))
+	for _, f := range factors {
+		// A zero total only happens when every compared factor has no weight
+		var points, weight float64
+		if totalWeight > 0 {
+			normalized := math.Max(-1, math.Min(1, f.difference/f.scale))
+			weight = f.weight / totalWeight
+			points = 50 * weight * normalized
+		}
+		score += points
+
+		contributions = append(contributions, types.FactorContribution{
+			Factor:     f.name,
+			Difference: math.Round(f.difference*100) / 100,
+			Weight:     math.Round(weight*1000) / 1000,
+			Points:     math.Round(points*100) / 100,
+		})
+	}
+
+	return math.Round(score*100) / 100, contributions
+}
+
+// multiplier treats an unset profile weight as neutral
+func multiplier(w float64) float64 {
+	if w <= 0 {
+		return 1
+	}
+	return w
+}
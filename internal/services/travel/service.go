@@ -2,59 +2,112 @@ package travel
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
-	"net/http"
+	"strings"
 	"time"
 
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/advisory"
+	"github.com/shuv1824/recommender/internal/utils/aqi"
+	"github.com/shuv1824/recommender/internal/utils/geo"
+	"github.com/shuv1824/recommender/internal/utils/geodata"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+	"github.com/shuv1824/recommender/internal/utils/uvindex"
+)
+
+// Data a recommendation can be based on
+const (
+	BasisForecast    = "forecast"
+	BasisClimatology = "climatology"
+	BasisHistorical  = "historical"
 )
 
 type TravelService struct {
-	httpClient *http.Client
-	districts  map[string]types.District // Map by name for quick lookup
+	provider  forecast.Provider
+	districts *geodata.Index // Lookup by ID or English or Bangla name
+	scoring   ScoringConfig
+	horizon   HorizonConfig
 }
 
-// NewTravelService creates a new travel service
-func NewTravelService(districts []types.District) *TravelService {
-	districtMap := make(map[string]types.District)
-	for _, d := range districts {
-		districtMap[d.Name] = d
+// NewTravelService creates a new travel service backed by the given forecast provider
+func NewTravelService(provider forecast.Provider, districts []types.District) *TravelService {
+	return &TravelService{
+		provider:  provider,
+		districts: geodata.NewIndex(districts, nil, nil),
+		scoring:   DefaultScoringConfig,
+		horizon:   DefaultHorizonConfig,
 	}
+}
 
-	return &TravelService{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 100,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		},
-		districts: districtMap,
+// SetUpazilas indexes upazilas so they can be chosen as destinations and
+// ranked within their district
+func (s *TravelService) SetUpazilas(upazilas []types.Upazila) {
+	idx := geodata.NewIndex(s.districts.Districts(), s.districts.Divisions(), upazilas)
+	idx.SetBoundaries(s.districts.Boundaries())
+	idx.SetCountry(s.districts.Country())
+	s.districts = idx
+}
+
+// SetBoundaries locates the current location with district boundary polygons
+// and rejects origins outside them
+func (s *TravelService) SetBoundaries(b *geodata.Boundaries) {
+	s.districts.SetBoundaries(b)
+}
+
+// SetCountry rejects origins and destination coordinates outside the outline
+// of Bangladesh when there are no district boundaries
+func (s *TravelService) SetCountry(b *geodata.Boundaries) {
+	s.districts.SetCountry(b)
+}
+
+// SetScoringConfig replaces the weights and verdict thresholds used to grade
+// recommendations
+func (s *TravelService) SetScoringConfig(cfg ScoringConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
 	}
+	s.scoring = cfg
+	return nil
+}
+
+// SetHorizonConfig replaces how far ahead forecasts and air-quality
+// forecasts are used
+func (s *TravelService) SetHorizonConfig(cfg HorizonConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	s.horizon = cfg
+	return nil
 }
 
 // GetRecommendation compares current location with destination and returns recommendation
 func (s *TravelService) GetRecommendation(ctx context.Context, req types.TravelRequest) (*types.TravelRecommendation, error) {
-	// Validate travel date
-	travelDate, err := time.Parse("2006-01-02", req.TravelDate)
+	plan, err := s.planDate(req.TravelDate)
 	if err != nil {
-		return nil, fmt.Errorf("invalid travel date format, use YYYY-MM-DD")
+		return nil, err
 	}
 
-	// Check if date is within forecast range (next 15 days)
-	now := time.Now().Truncate(24 * time.Hour)
-	maxDate := now.AddDate(0, 0, 7)
-	if travelDate.Before(now) || travelDate.After(maxDate) {
-		return nil, fmt.Errorf("travel date must be within the next 7 days")
+	origin, err := s.locateOrigin(req.CurrentLocation)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get destination district
-	destination, ok := s.districts[req.DestinationDistrictName]
-	if !ok {
-		return nil, fmt.Errorf("destination district not found")
+	destination, err := s.resolveDestination(req.DestinationDistrictID, req.DestinationDistrictName, req.DestinationUpazila, req.DestinationLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	pollutants, err := pollutant.Normalize(req.Pollutants)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := LookupProfile(req.TravelerProfile)
+	if err != nil {
+		return nil, err
 	}
 
 	// Fetch weather data for both locations concurrently
@@ -68,26 +121,68 @@ func (s *TravelService) GetRecommendation(ctx context.Context, req types.TravelR
 
 	// Get weather forecast for current location
 	go func() {
-		temp, pm25, err := s.fetchWeatherForDate(ctx, req.CurrentLocation.Lat, req.CurrentLocation.Long, req.TravelDate)
-		name := req.CurrentLocation.Name
-		if name == "" {
-			name = "Current Location"
-		}
-		currentCh <- weatherResult{
-			weather: types.LocationWeather{Name: name, Temp2PM: temp, PM25: pm25},
-			err:     err,
+		weather, err := plan.fetchWeather(ctx, req.CurrentLocation.Lat, req.CurrentLocation.Long, req.TravelDate)
+		weather.Name = req.CurrentLocation.Name
+		if weather.Name == "" {
+			weather.Name = "Current Location"
 		}
+		currentCh <- weatherResult{weather: weather, err: err}
 	}()
 
 	// Get weather forecast for destination
 	go func() {
-		temp, pm25, err := s.fetchWeatherForDate(ctx, destination.Lat, destination.Long, req.TravelDate)
-		destCh <- weatherResult{
-			weather: types.LocationWeather{Name: destination.Name, Temp2PM: temp, PM25: pm25},
-			err:     err,
-		}
+		weather, err := plan.fetchWeather(ctx, destination.Lat, destination.Long, req.TravelDate)
+		weather.Name = destination.Name
+		weather.District = destination.DistrictName
+		destCh <- weatherResult{weather: weather, err: err}
 	}()
 
+	// Optionally sample the route between the two
+	type routeResult struct {
+		weather []types.LocationWeather
+		err     error
+	}
+
+	var points []geo.PathPoint
+	var routeCh chan routeResult
+	if req.Route != nil {
+		points, err = routePoints(req.CurrentLocation, destination.District, req.Route)
+		if err != nil {
+			return nil, err
+		}
+
+		routeCh = make(chan routeResult, 1)
+		go func() {
+			weather, err := s.fetchRouteWeather(ctx, plan, req.TravelDate, points)
+			routeCh <- routeResult{weather: weather, err: err}
+		}()
+	}
+
+	// Optionally fetch ensemble members alongside the deterministic forecast
+	type ensembleResult struct {
+		members map[string]float64
+		err     error
+	}
+
+	// Ensembles only cover the forecast range
+	useEnsemble := req.Ensemble && plan.basis == BasisForecast
+
+	var currentEnsembleCh, destEnsembleCh chan ensembleResult
+	if useEnsemble {
+		currentEnsembleCh = make(chan ensembleResult, 1)
+		destEnsembleCh = make(chan ensembleResult, 1)
+
+		go func() {
+			members, err := s.fetchTemperatureEnsemble(ctx, req.CurrentLocation.Lat, req.CurrentLocation.Long, req.TravelDate)
+			currentEnsembleCh <- ensembleResult{members: members, err: err}
+		}()
+
+		go func() {
+			members, err := s.fetchTemperatureEnsemble(ctx, destination.Lat, destination.Long, req.TravelDate)
+			destEnsembleCh <- ensembleResult{members: members, err: err}
+		}()
+	}
+
 	currentResult := <-currentCh
 	destResult := <-destCh
 
@@ -98,185 +193,427 @@ func (s *TravelService) GetRecommendation(ctx context.Context, req types.TravelR
 		return nil, fmt.Errorf("failed to fetch destination weather: %w", destResult.err)
 	}
 
+	// Ensemble failures only lower confidence, they don't fail the request
+	var currentMembers, destMembers map[string]float64
+	var ensembleErr error
+	if useEnsemble {
+		currentEnsemble := <-currentEnsembleCh
+		destEnsemble := <-destEnsembleCh
+
+		ensembleErr = errors.Join(currentEnsemble.err, destEnsemble.err)
+		if ensembleErr == nil {
+			currentMembers, destMembers = currentEnsemble.members, destEnsemble.members
+			currentResult.weather.TempEnsemble = ensembleStats(memberValues(currentMembers))
+			destResult.weather.TempEnsemble = ensembleStats(memberValues(destMembers))
+		}
+	}
+
+	// Per-model values are only exposed on request
+	if !req.Debug {
+		currentResult.weather.Debug = nil
+		destResult.weather.Debug = nil
+	}
+
+	c := comparison{
+		date:              req.TravelDate,
+		basis:             plan.basis,
+		missingAirQuality: plan.missingAirQuality,
+		pollutants:        pollutants,
+		profile:           profile,
+		confidence:        plan.confidence(currentMembers, destMembers, ensembleErr),
+	}
+	rec := s.compare(currentResult.weather, destResult.weather, c)
+	rec.Origin = origin
+
+	if routeCh != nil {
+		// Like ensembles, an unavailable route doesn't fail the request
+		route := <-routeCh
+		if route.err != nil {
+			rec.Route = unavailableRoute(points, fmt.Errorf("failed to fetch route weather: %w", route.err))
+			return rec, nil
+		}
+
+		weather := append([]types.LocationWeather{currentResult.weather}, route.weather...)
+		weather = append(weather, destResult.weather)
+		rec.Route = s.routeReport(points, weather, c)
+
+		if worst := rec.Route.WorstSegment; worst.ExtremeHeat {
+			rec.Reason += fmt.Sprintf(" The route reaches %.1f°C between %.0f and %.0f km, a severe heat wave.", worst.MaxTemp2PM, worst.FromKm, worst.ToKm)
+		}
+	}
+
+	return rec, nil
+}
+
+// datePlan is how weather for a travel date is sourced
+type datePlan struct {
+	leadDays     int
+	basis        string
+	fetchWeather func(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error)
+	// missingAirQuality explains why only temperature can be compared
+	missingAirQuality string
+}
+
+// planDate validates a travel date and picks the data behind it. Past dates
+// use observed data, dates within the forecast range use the forecast and
+// later ones fall back to climate normals.
+func (s *TravelService) planDate(date string) (datePlan, error) {
+	travelDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return datePlan{}, fmt.Errorf("invalid travel date format, use YYYY-MM-DD")
+	}
+
+	now := today()
+	if travelDate.Before(historyStart) || travelDate.After(now.AddDate(0, 0, climateMaxDays)) {
+		return datePlan{}, fmt.Errorf("travel date must be between %s and %d days from today", historyStart.Format("2006-01-02"), climateMaxDays)
+	}
+
+	// Horizons count today as the first day
+	plan := datePlan{
+		leadDays:     int(travelDate.Sub(now).Hours() / 24),
+		basis:        BasisForecast,
+		fetchWeather: s.fetchWeatherForDate,
+	}
+	switch {
+	case travelDate.Before(airQualityHistoryStart):
+		plan.basis = BasisHistorical
+		plan.fetchWeather = s.fetchObservedTemperatureForDate
+		plan.missingAirQuality = fmt.Sprintf("air-quality history starts on %s", airQualityHistoryStart.Format("2006-01-02"))
+	case travelDate.Before(now):
+		plan.basis = BasisHistorical
+		plan.fetchWeather = s.fetchObservedForDate
+	case plan.leadDays >= s.horizon.ForecastDays:
+		plan.basis = BasisClimatology
+		plan.fetchWeather = s.fetchClimateNormals
+	case plan.leadDays >= s.horizon.AirQualityDays:
+		plan.fetchWeather = s.fetchTemperatureForDate
+		plan.missingAirQuality = fmt.Sprintf("air quality is only forecast %d days ahead", s.horizon.AirQualityDays)
+	}
+
+	return plan, nil
+}
+
+// confidence rates the plan's data; ensemble members only apply to forecasts
+func (p datePlan) confidence(currentMembers, destMembers map[string]float64, ensembleErr error) types.ForecastConfidence {
+	switch p.basis {
+	case BasisHistorical:
+		return observedConfidence()
+	case BasisClimatology:
+		return climatologyConfidence()
+	default:
+		return forecastConfidence(p.leadDays, currentMembers, destMembers, ensembleErr)
+	}
+}
+
+// comparison describes how two locations are compared on one day
+type comparison struct {
+	date  string
+	basis string
+	// missingAirQuality explains why only temperature is compared; empty when
+	// air quality is available
+	missingAirQuality string
+	pollutants        []string
+	profile           TravelerProfile
+	confidence        types.ForecastConfidence
+}
+
+// compare scores the destination against the current location and explains the verdict
+func (s *TravelService) compare(current, dest types.LocationWeather, c comparison) *types.TravelRecommendation {
+	// Climate normals go without air quality when its history is unavailable
+	if c.basis == BasisClimatology && c.missingAirQuality == "" && (!hasAirQuality(current) || !hasAirQuality(dest)) {
+		c.missingAirQuality = fmt.Sprintf("no air-quality history for this time of year in the past %d years", climateAirQualityYears)
+	}
+	temperatureOnly := c.missingAirQuality != ""
+	pollutants := c.pollutants
+	profile := c.profile
+	confidence := c.confidence
+	if temperatureOnly {
+		confidence.Reasons = append(confidence.Reasons, "temperature compared only: "+c.missingAirQuality)
+	}
+
 	// Calculate differences
-	tempDiff := math.Round((currentResult.weather.Temp2PM-destResult.weather.Temp2PM)*100) / 100
-	pm25Diff := math.Round((currentResult.weather.PM25-destResult.weather.PM25)*100) / 100
+	tempDiff := math.Round((current.Temp2PM-dest.Temp2PM)*100) / 100
+	pm25Diff := math.Round((current.PM25-dest.PM25)*100) / 100
+
+	// UV is only compared when both locations have it
+	var uvDiff *float64
+	if current.UVIndex != nil && dest.UVIndex != nil {
+		diff := math.Round((*current.UVIndex-*dest.UVIndex)*100) / 100
+		uvDiff = &diff
+	}
+
+	currentAQ := current.AirQuality()
+	destAQ := dest.AirQuality()
+
+	// Without air-quality data only temperature (and UV) is compared
+	pollutantDiffs := map[string]float64{}
+	var aqDiff float64
+	isCleaner := false
+	if temperatureOnly {
+		pollutants = []string{}
+	} else {
+		pollutantDiffs = pollutant.Differences(currentAQ, destAQ, pollutants)
+		for k, v := range pollutantDiffs {
+			pollutantDiffs[k] = math.Round(v*100) / 100
+		}
+
+		// Express the combined pollutant difference in PM2.5-equivalent µg/m³ so
+		// the reason thresholds mean the same thing whichever pollutants are compared
+		currentScore := pollutant.Score(currentAQ, pollutants)
+		destScore := pollutant.Score(destAQ, pollutants)
+		aqDiff = (currentScore - destScore) * pollutant.ReferenceLevel(pollutant.PM25)
+		isCleaner = destScore < currentScore
+	}
 
 	// Determine recommendation
-	isCooler := destResult.weather.Temp2PM < currentResult.weather.Temp2PM
-	isCleaner := destResult.weather.PM25 < currentResult.weather.PM25
+	isCooler := dest.Temp2PM < current.Temp2PM
+
+	// Profile limits can veto a destination that is otherwise cooler and cleaner
+	profileRules := profile.applyRules(dest, !temperatureOnly)
+	var failedRules []string
+	for _, r := range profileRules {
+		if !r.Passed {
+			failedRules = append(failedRules, r.Rule)
+		}
+	}
+
+	// Grade by how much better or worse the destination is, not just the sign
+	diffs := factorDifferences{
+		temp:              tempDiff,
+		airQuality:        aqDiff,
+		withoutAirQuality: temperatureOnly,
+		withoutUV:         uvDiff == nil,
+	}
+	if uvDiff != nil {
+		diffs.uv = *uvDiff
+	}
+	score, factors := s.scoring.score(diffs, profile)
+	if len(failedRules) > 0 {
+		// A failed profile limit caps the verdict at "Not Recommended"
+		score = math.Min(score, s.scoring.Thresholds.Neutral-0.01)
+	}
+	recommended := s.scoring.verdict(score)
+
+	var destUV float64
+	if dest.UVIndex != nil {
+		destUV = *dest.UVIndex
+	}
+
+	var reason string
+	if temperatureOnly {
+		reason = s.generateTemperatureReason(isCooler, tempDiff, destUV, dest.Name, c.missingAirQuality)
+	} else {
+		reason = s.generateReason(isCooler, isCleaner, tempDiff, aqDiff, destUV, dest.Name)
+	}
+	if len(failedRules) > 0 {
+		reason += fmt.Sprintf(" Not advised for %s travelers: %s.", profile.Name, strings.Join(failedRules, "; "))
+	}
 
-	recommended := "Not Recommended"
-	if isCleaner && isCooler {
-		recommended = "Recommended"
+	if c.basis == BasisHistorical {
+		reason += " This report uses observed weather for a past date."
+	} else if c.basis == BasisClimatology {
+		reason += fmt.Sprintf(" This date is beyond the %d-day forecast, so it is based on typical conditions from the past %d years; check again closer to your trip.", s.horizon.ForecastDays, climateYears)
+	} else if confidence.Level == ConfidenceLow {
+		reason += " Forecast confidence is low for this date, so check again closer to your trip."
 	}
 
-	reason := s.generateReason(isCooler, isCleaner, tempDiff, pm25Diff, destination.Name)
+	// Health advice for the destination, judged against (profile-scaled) WHO guideline values
+	healthAdvisory := advisory.AssessScaled(destAQ, profile.GuidelineFactor)
+	if temperatureOnly {
+		healthAdvisory = advisory.Unavailable()
+	}
+	if len(healthAdvisory.Exceedances) > 0 {
+		reason += fmt.Sprintf(" In %s, %s", dest.Name, healthAdvisory.Summary)
+	}
 
 	return &types.TravelRecommendation{
-		Recommendation:     recommended,
-		Reason:             reason,
-		TravelDate:         req.TravelDate,
-		CurrentWeather:     currentResult.weather,
-		DestinationWeather: destResult.weather,
-		TempDifference:     tempDiff,
-		PM25Difference:     pm25Diff,
-	}, nil
+		Recommendation:       recommended,
+		Score:                score,
+		Basis:                c.basis,
+		TemperatureOnly:      temperatureOnly,
+		Confidence:           confidence,
+		Factors:              factors,
+		Reason:               reason,
+		TravelDate:           c.date,
+		CurrentWeather:       current,
+		DestinationWeather:   dest,
+		TempDifference:       tempDiff,
+		PM25Difference:       pm25Diff,
+		Pollutants:           pollutants,
+		PollutantDifferences: pollutantDiffs,
+		AQIDifference:        current.AQI.USEPA.Value - dest.AQI.USEPA.Value,
+		UVIndexDifference:    uvDiff,
+		HealthAdvisory:       healthAdvisory,
+		TravelerProfile:      profile.report(profileRules),
+	}
 }
 
-// fetchWeatherForDate fetches temperature and PM2.5 at 2PM for a specific date
-func (s *TravelService) fetchWeatherForDate(ctx context.Context, lat, long float64, date string) (float64, float64, error) {
-	type result struct {
-		value float64
+// fetchWeatherForDate fetches temperature, UV index and air quality at 2PM for a specific date
+func (s *TravelService) fetchWeatherForDate(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error) {
+	type forecastResult struct {
+		temp  float64
+		uv    []float64
+		debug *types.ForecastDebug
 		err   error
 	}
+	type airQualityResult struct {
+		value  types.Pollutants
+		report types.AQIReport
+		err    error
+	}
 
-	tempCh := make(chan result, 1)
-	pm25Ch := make(chan result, 1)
+	forecastCh := make(chan forecastResult, 1)
+	aqCh := make(chan airQualityResult, 1)
 
-	// Fetch temperature
+	// Fetch temperature and UV index
 	go func() {
-		temp, err := s.fetchTemperature(ctx, lat, long, date)
-		tempCh <- result{value: temp, err: err}
+		temp, uv, debug, err := s.fetchForecast(ctx, lat, long, date)
+		forecastCh <- forecastResult{temp: temp, uv: uv, debug: debug, err: err}
 	}()
 
 	// Fetch air quality
 	go func() {
-		pm25, err := s.fetchPM25(ctx, lat, long, date)
-		pm25Ch <- result{value: pm25, err: err}
+		aq, report, err := s.fetchAirQuality(ctx, lat, long, date)
+		aqCh <- airQualityResult{value: aq, report: report, err: err}
 	}()
 
-	tempResult := <-tempCh
-	pm25Result := <-pm25Ch
+	fc := <-forecastCh
+	aqResult := <-aqCh
 
-	if tempResult.err != nil {
-		return 0, 0, tempResult.err
+	if fc.err != nil {
+		return types.LocationWeather{}, fc.err
 	}
-	if pm25Result.err != nil {
-		return 0, 0, pm25Result.err
+	if aqResult.err != nil {
+		return types.LocationWeather{}, aqResult.err
 	}
 
-	return tempResult.value, pm25Result.value, nil
+	aq := aqResult.value
+	return types.LocationWeather{
+		Temp2PM:    fc.temp,
+		PM25:       aq.PM25,
+		PM10:       aq.PM10,
+		NO2:        aq.NO2,
+		O3:         aq.O3,
+		SO2:        aq.SO2,
+		CO:         aq.CO,
+		AQI:        aqResult.report,
+		UVIndex:    uvIndex(fc.uv),
+		UVCategory: uvCategory(fc.uv),
+		Debug:      fc.debug,
+	}, nil
 }
 
-// fetchTemperature fetches temperature at 2PM for a specific date
-func (s *TravelService) fetchTemperature(ctx context.Context, lat, long float64, date string) (float64, error) {
-	url := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=temperature_2m&start_date=%s&end_date=%s&timezone=auto",
-		lat, long, date, date,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := s.httpClient.Do(req)
+// fetchTemperatureForDate fetches temperature and UV index at 2PM for a date
+// beyond the air-quality forecast; pollutant fields are left at zero
+func (s *TravelService) fetchTemperatureForDate(ctx context.Context, lat, long float64, date string) (types.LocationWeather, error) {
+	temp, uv, debug, err := s.fetchForecast(ctx, lat, long, date)
 	if err != nil {
-		return 0, err
+		return types.LocationWeather{}, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("weather API returned status %d", resp.StatusCode)
-	}
+	return types.LocationWeather{
+		Temp2PM:    temp,
+		UVIndex:    uvIndex(uv),
+		UVCategory: uvCategory(uv),
+		Debug:      debug,
+	}, nil
+}
 
-	var data struct {
-		Hourly struct {
-			Time          []string  `json:"time"`
-			Temperature2m []float64 `json:"temperature_2m"`
-		} `json:"hourly"`
+// fetchForecast fetches temperature and UV index at 2PM for a specific date.
+// The UV index is returned as the values found, none when it is missing.
+func (s *TravelService) fetchForecast(ctx context.Context, lat, long float64, date string) (float64, []float64, *types.ForecastDebug, error) {
+	hourly, err := s.provider.Forecast(ctx, lat, long, forecast.Query{StartDate: date, EndDate: date})
+	if err != nil {
+		return 0, nil, nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+	// Find temperature and UV index at 2PM (14:00)
+	indices := forecast.HourIndices(hourly.Time, 14)
+	temps := forecast.ValuesAt(hourly.Temperature, indices)
+	if len(temps) == 0 {
+		return 0, nil, nil, fmt.Errorf("no 2PM temperature data found")
 	}
 
-	// Find temperature at 2PM (14:00)
-	for i, timeStr := range data.Hourly.Time {
-		if len(timeStr) >= 13 && timeStr[11:13] == "14" {
-			if i < len(data.Hourly.Temperature2m) {
-				return math.Round(data.Hourly.Temperature2m[i]*100) / 100, nil
-			}
+	var debug *types.ForecastDebug
+	if len(hourly.Models) > 0 {
+		debug = &types.ForecastDebug{
+			BlendMethod:     hourly.BlendMethod,
+			ModelTemp2PM:    make(map[string]float64, len(hourly.Models)),
+			ModelUVIndex2PM: make(map[string]float64, len(hourly.Models)),
+		}
+		for model, h := range hourly.Models {
+			modelIndices := forecast.HourIndices(h.Time, 14)
+			debug.ModelTemp2PM[model] = firstValue(forecast.ValuesAt(h.Temperature, modelIndices))
+			debug.ModelUVIndex2PM[model] = firstValue(forecast.ValuesAt(h.UVIndex, modelIndices))
 		}
 	}
 
-	return 0, fmt.Errorf("no 2PM temperature data found")
+	return round2(temps[0]), forecast.ValuesAt(hourly.UVIndex, indices), debug, nil
 }
 
-// fetchPM25 fetches PM2.5 at 2PM for a specific date
-func (s *TravelService) fetchPM25(ctx context.Context, lat, long float64, date string) (float64, error) {
-	url := fmt.Sprintf(
-		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&hourly=pm2_5&start_date=%s&end_date=%s&timezone=auto",
-		lat, long, date, date,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchAirQuality fetches pollutant levels at 2PM for a specific date, and
+// the AQI from the same day's concentrations averaged over each pollutant's
+// breakpoint period
+func (s *TravelService) fetchAirQuality(ctx context.Context, lat, long float64, date string) (types.Pollutants, types.AQIReport, error) {
+	hourly, err := s.provider.AirQuality(ctx, lat, long, forecast.Query{StartDate: date, EndDate: date})
 	if err != nil {
-		return 0, err
+		return types.Pollutants{}, types.AQIReport{}, err
 	}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return 0, err
+	// Find pollutant levels at 2PM (14:00); PM2.5 is required, the rest are best-effort
+	indices := forecast.HourIndices(hourly.Time, 14)
+	pm25 := forecast.ValuesAt(hourly.PM25, indices)
+	if len(pm25) == 0 {
+		return types.Pollutants{}, types.AQIReport{}, fmt.Errorf("no 2PM PM2.5 data found")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("air quality API returned status %d", resp.StatusCode)
-	}
+	return types.Pollutants{
+		PM25: round2(pm25[0]),
+		PM10: firstValue(forecast.ValuesAt(hourly.PM10, indices)),
+		NO2:  firstValue(forecast.ValuesAt(hourly.NO2, indices)),
+		O3:   firstValue(forecast.ValuesAt(hourly.O3, indices)),
+		SO2:  firstValue(forecast.ValuesAt(hourly.SO2, indices)),
+		CO:   firstValue(forecast.ValuesAt(hourly.CO, indices)),
+	}, aqi.Report(hourly.AQIInputs(indices[0])), nil
+}
 
-	var data struct {
-		Hourly struct {
-			Time []string  `json:"time"`
-			PM25 []float64 `json:"pm2_5"`
-		} `json:"hourly"`
+// firstValue returns the first value rounded to 2 decimal places, or 0 if empty
+func firstValue(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
 	}
+	return round2(values[0])
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+// uvIndex is the first UV index value, nil when UV is missing
+func uvIndex(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
 	}
+	uv := round2(values[0])
+	return &uv
+}
 
-	// Find PM2.5 at 2PM (14:00)
-	for i, timeStr := range data.Hourly.Time {
-		if len(timeStr) >= 13 && timeStr[11:13] == "14" {
-			if i < len(data.Hourly.PM25) {
-				return math.Round(data.Hourly.PM25[i]*100) / 100, nil
-			}
-		}
+// uvCategory labels the first UV index value. Missing UV has no category,
+// rather than counting as low.
+func uvCategory(values []float64) string {
+	if len(values) == 0 {
+		return ""
 	}
-
-	return 0, fmt.Errorf("no 2PM PM2.5 data found")
+	return uvindex.Category(values[0])
 }
 
 // generateReason creates a human-readable recommendation reason
-func (s *TravelService) generateReason(isCooler, isCleaner bool, tempDiff, pm25Diff float64, destName string) string {
-	absTempDiff := math.Abs(tempDiff)
-	absPM25Diff := math.Abs(pm25Diff)
-
-	// Classify temperature difference
-	var tempDesc string
-	if absTempDiff < 1 {
-		tempDesc = "about the same temperature"
-	} else if absTempDiff < 3 {
-		if isCooler {
-			tempDesc = fmt.Sprintf("%.1f°C cooler", absTempDiff)
-		} else {
-			tempDesc = fmt.Sprintf("%.1f°C hotter", absTempDiff)
-		}
-	} else {
-		if isCooler {
-			tempDesc = fmt.Sprintf("significantly cooler (%.1f°C less)", absTempDiff)
-		} else {
-			tempDesc = fmt.Sprintf("significantly hotter (%.1f°C more)", absTempDiff)
-		}
-	}
+// aqDiff is the air-quality difference in PM2.5-equivalent µg/m³
+func (s *TravelService) generateReason(isCooler, isCleaner bool, tempDiff, aqDiff, destUV float64, destName string) string {
+	tempDesc := describeTempDiff(isCooler, tempDiff)
+	absAQDiff := math.Abs(aqDiff)
 
 	// Classify air quality difference
 	var aqDesc string
-	if absPM25Diff < 5 {
+	if absAQDiff < 5 {
 		aqDesc = "similar air quality"
-	} else if absPM25Diff < 15 {
+	} else if absAQDiff < 15 {
 		if isCleaner {
 			aqDesc = "better air quality"
 		} else {
@@ -291,13 +628,54 @@ func (s *TravelService) generateReason(isCooler, isCleaner bool, tempDiff, pm25D
 	}
 
 	// Generate final recommendation message
+	var reason string
 	if isCooler && isCleaner {
-		return fmt.Sprintf("%s is %s and has %s. Enjoy your trip! 🌴", destName, tempDesc, aqDesc)
+		reason = fmt.Sprintf("%s is %s and has %s. Enjoy your trip! 🌴", destName, tempDesc, aqDesc)
 	} else if isCooler && !isCleaner {
-		return fmt.Sprintf("%s is %s but has %s. Consider wearing a mask if you decide to travel.", destName, tempDesc, aqDesc)
+		reason = fmt.Sprintf("%s is %s but has %s. Consider wearing a mask if you decide to travel.", destName, tempDesc, aqDesc)
 	} else if !isCooler && isCleaner {
-		return fmt.Sprintf("%s is %s but has %s. Pack light clothes if you go!", destName, tempDesc, aqDesc)
+		reason = fmt.Sprintf("%s is %s but has %s. Pack light clothes if you go!", destName, tempDesc, aqDesc)
 	} else {
-		return fmt.Sprintf("%s is %s and has %s. It's better to stay where you are or choose another destination.", destName, tempDesc, aqDesc)
+		reason = fmt.Sprintf("%s is %s and has %s. It's better to stay where you are or choose another destination.", destName, tempDesc, aqDesc)
+	}
+
+	// Append sun-protection guidance when the destination's midday UV is high
+	if advice := uvindex.Advice(destUV); advice != "" {
+		reason += " " + advice
+	}
+
+	return reason
+}
+
+// generateTemperatureReason creates a reason when only temperature was compared.
+// missingAirQuality explains why air quality is missing.
+func (s *TravelService) generateTemperatureReason(isCooler bool, tempDiff, destUV float64, destName, missingAirQuality string) string {
+	reason := fmt.Sprintf("%s is %s.", destName, describeTempDiff(isCooler, tempDiff))
+	if !isCooler && math.Abs(tempDiff) >= 1 {
+		reason += " Pack light clothes if you go!"
+	}
+	reason += fmt.Sprintf(" Air quality wasn't compared: %s.", missingAirQuality)
+
+	if advice := uvindex.Advice(destUV); advice != "" {
+		reason += " " + advice
+	}
+
+	return reason
+}
+
+// describeTempDiff classifies a temperature difference for reason messages
+func describeTempDiff(isCooler bool, tempDiff float64) string {
+	absTempDiff := math.Abs(tempDiff)
+	if absTempDiff < 1 {
+		return "about the same temperature"
+	} else if absTempDiff < 3 {
+		if isCooler {
+			return fmt.Sprintf("%.1f°C cooler", absTempDiff)
+		}
+		return fmt.Sprintf("%.1f°C hotter", absTempDiff)
+	}
+	if isCooler {
+		return fmt.Sprintf("significantly cooler (%.1f°C less)", absTempDiff)
 	}
+	return fmt.Sprintf("significantly hotter (%.1f°C more)", absTempDiff)
 }
@@ -2,45 +2,85 @@ package travel
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geodata"
 )
 
-// mockTransport is a mock HTTP transport for testing
+// mockTransport is a mock HTTP transport for testing. A response keyed
+// "<key>@<date>" takes precedence for requests starting on that date.
+// Coordinates other than the origin and Cox's Bazar use "<kind>_other".
 type mockTransport struct {
 	responses map[string]string
+
+	mu        sync.Mutex
+	requested map[string]int // Requests per response key
 }
 
 func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	url := req.URL.String()
 
 	// Determine which mock response to return based on URL
-	var body string
+	var key string
 	if strings.Contains(url, "api.open-meteo.com/v1/forecast") {
 		// Temperature API
 		if strings.Contains(url, "latitude=23.8103") {
 			// Current location (Dhaka)
-			body = m.responses["temp_current"]
+			key = "temp_current"
 		} else if strings.Contains(url, "latitude=22.3569") {
 			// Cox's Bazar
-			body = m.responses["temp_dest"]
+			key = "temp_dest"
+		} else {
+			key = "temp_other"
+		}
+	} else if strings.Contains(url, "ensemble-api.open-meteo.com") {
+		// Ensemble API
+		if strings.Contains(url, "latitude=23.8103") {
+			key = "ensemble_current"
+		} else if strings.Contains(url, "latitude=22.3569") {
+			key = "ensemble_dest"
+		}
+	} else if strings.Contains(url, "archive-api.open-meteo.com") {
+		// Historical archive API
+		if strings.Contains(url, "latitude=23.8103") {
+			key = "archive_current"
+		} else if strings.Contains(url, "latitude=22.3569") {
+			key = "archive_dest"
 		}
 	} else if strings.Contains(url, "air-quality-api.open-meteo.com") {
 		// Air quality API
 		if strings.Contains(url, "latitude=23.8103") {
 			// Current location (Dhaka)
-			body = m.responses["pm25_current"]
+			key = "pm25_current"
 		} else if strings.Contains(url, "latitude=22.3569") {
 			// Cox's Bazar
-			body = m.responses["pm25_dest"]
+			key = "pm25_dest"
+		} else {
+			key = "pm25_other"
 		}
 	}
 
+	m.mu.Lock()
+	if m.requested == nil {
+		m.requested = make(map[string]int)
+	}
+	m.requested[key]++
+	m.mu.Unlock()
+
+	body := m.responses[key]
+	if dated, ok := m.responses[key+"@"+req.URL.Query().Get("start_date")]; ok {
+		body = dated
+	}
+
 	return &http.Response{
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(body)),
@@ -51,17 +91,29 @@ func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 func TestGetRecommendation(t *testing.T) {
 	// Create tomorrow's date for testing
 	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	inTenDays := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	twoMonths := time.Now().AddDate(0, 2, 0)
+	inTwoMonths := twoMonths.Format("2006-01-02")
+
+	// Climate normals sample the days around the same calendar day in past years
+	lastYear := twoMonths.AddDate(-1, 0, 0).Format("2006-01-02")
+	twoYearsAgo := twoMonths.AddDate(-2, 0, 2).Format("2006-01-02")
+	outsideWindow := twoMonths.AddDate(-1, 0, 10).Format("2006-01-02")
 
 	tests := []struct {
 		name               string
 		request            types.TravelRequest
 		mockResponses      map[string]string
 		expectedRecommend  string
+		expectedUVCategory string
+		expectNoUV         bool
+		expectedBasis      string
+		expectTempOnly     bool
 		expectError        bool
 		errorContains      string
 	}{
 		{
-			name: "recommended when destination is cooler and cleaner",
+			name: "strongly recommended when destination is much cooler and cleaner",
 			request: types.TravelRequest{
 				CurrentLocation: types.Location{
 					Lat:  23.8103,
@@ -72,13 +124,14 @@ func TestGetRecommendation(t *testing.T) {
 				TravelDate:              tomorrow,
 			},
 			mockResponses: map[string]string{
-				"temp_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5]}}`,
-				"temp_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0]}}`,
+				"temp_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5],"uv_index":[5.1]}}`,
+				"temp_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0],"uv_index":[8.4]}}`,
 				"pm25_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[75.0]}}`,
 				"pm25_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[25.0]}}`,
 			},
-			expectedRecommend: "Recommended",
-			expectError:       false,
+			expectedRecommend:  "Strongly Recommended",
+			expectedUVCategory: "very high",
+			expectError:        false,
 		},
 		{
 			name: "not recommended when destination is hotter",
@@ -95,11 +148,210 @@ func TestGetRecommendation(t *testing.T) {
 				"temp_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0]}}`,
 				"temp_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.0]}}`,
 				"pm25_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[75.0]}}`,
-				"pm25_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[25.0]}}`,
+				"pm25_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[70.0]}}`,
+			},
+			expectedRecommend: "Not Recommended",
+			expectNoUV:        true,
+			expectError:       false,
+		},
+		{
+			name: "much worse ozone offsets a cooler destination",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              tomorrow,
+				Pollutants:              []string{"o3", "no2"},
+			},
+			mockResponses: map[string]string{
+				"temp_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5]}}`,
+				"temp_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0]}}`,
+				"pm25_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[75.0],"ozone":[60.0],"nitrogen_dioxide":[30.0]}}`,
+				"pm25_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[25.0],"ozone":[300.0],"nitrogen_dioxide":[60.0]}}`,
+			},
+			expectedRecommend: "Neutral",
+			expectError:       false,
+		},
+		{
+			name: "asthma profile vetoes destination above its PM2.5 limit",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              tomorrow,
+				TravelerProfile:         "asthma",
+			},
+			mockResponses: map[string]string{
+				"temp_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5]}}`,
+				"temp_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0]}}`,
+				"pm25_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[75.0]}}`,
+				"pm25_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[30.0]}}`,
 			},
 			expectedRecommend: "Not Recommended",
 			expectError:       false,
 		},
+		{
+			name: "unknown traveler profile returns error",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              tomorrow,
+				TravelerProfile:         "astronaut",
+			},
+			expectError:   true,
+			errorContains: "unknown traveler profile",
+		},
+		{
+			name: "ensemble request reports spread and confidence",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              tomorrow,
+				Ensemble:                true,
+			},
+			mockResponses: map[string]string{
+				"temp_current":     `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5]}}`,
+				"temp_dest":        `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0]}}`,
+				"pm25_current":     `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[75.0]}}`,
+				"pm25_dest":        `{"hourly":{"time":["` + tomorrow + `T14:00"],"pm2_5":[25.0]}}`,
+				"ensemble_current": `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[35.5],"temperature_2m_member01":[35.0],"temperature_2m_member02":[36.1]}}`,
+				"ensemble_dest":    `{"hourly":{"time":["` + tomorrow + `T14:00"],"temperature_2m":[28.0],"temperature_2m_member01":[27.2],"temperature_2m_member02":[29.0]}}`,
+			},
+			expectedRecommend: "Strongly Recommended",
+			expectError:       false,
+		},
+		{
+			name: "unknown pollutant returns error",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              tomorrow,
+				Pollutants:              []string{"pm25", "radon"},
+			},
+			expectError:   true,
+			errorContains: "unknown pollutant",
+		},
+		{
+			name: "date beyond the air-quality forecast compares temperature only",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              inTenDays,
+				TravelerProfile:         "asthma",
+			},
+			mockResponses: map[string]string{
+				"temp_current": `{"hourly":{"time":["` + inTenDays + `T14:00"],"temperature_2m":[35.5]}}`,
+				"temp_dest":    `{"hourly":{"time":["` + inTenDays + `T14:00"],"temperature_2m":[28.0]}}`,
+			},
+			expectedRecommend: "Strongly Recommended",
+			expectTempOnly:    true,
+			expectError:       false,
+		},
+		{
+			name: "date beyond the forecast range uses climate normals",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              inTwoMonths,
+				Ensemble:                true,
+			},
+			mockResponses: map[string]string{
+				"archive_current": `{"hourly":{"time":["` + lastYear + `T14:00","` + outsideWindow + `T14:00","` + twoYearsAgo + `T14:00"],"temperature_2m":[35.0,50.0,36.0]}}`,
+				"archive_dest":    `{"hourly":{"time":["` + lastYear + `T14:00","` + twoYearsAgo + `T14:00"],"temperature_2m":[28.0,null]}}`,
+				"pm25_current":    `{"hourly":{"time":["` + lastYear + `T14:00"],"pm2_5":[75.0]}}`,
+				"pm25_dest":       `{"hourly":{"time":["` + lastYear + `T14:00"],"pm2_5":[25.0]}}`,
+			},
+			expectedRecommend: "Strongly Recommended",
+			expectedBasis:     "climatology",
+			expectError:       false,
+		},
+		{
+			name: "climate normals without air-quality history compare temperature only",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              inTwoMonths,
+			},
+			mockResponses: map[string]string{
+				"archive_current": `{"hourly":{"time":["` + lastYear + `T14:00","` + twoYearsAgo + `T14:00"],"temperature_2m":[35.0,36.0]}}`,
+				"archive_dest":    `{"hourly":{"time":["` + lastYear + `T14:00"],"temperature_2m":[28.0]}}`,
+				"pm25_current":    `{"hourly":{"time":[],"pm2_5":[]}}`,
+				"pm25_dest":       `{"hourly":{"time":["` + lastYear + `T14:00"],"pm2_5":[25.0]}}`,
+			},
+			expectedRecommend: "Strongly Recommended",
+			expectedBasis:     "climatology",
+			expectTempOnly:    true,
+			expectError:       false,
+		},
+		{
+			name: "past date uses observed data",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              "2024-04-10",
+			},
+			mockResponses: map[string]string{
+				"archive_current": `{"hourly":{"time":["2024-04-10T13:00","2024-04-10T14:00"],"temperature_2m":[34.0,35.5]}}`,
+				"archive_dest":    `{"hourly":{"time":["2024-04-10T13:00","2024-04-10T14:00"],"temperature_2m":[27.0,28.0]}}`,
+				"pm25_current":    `{"hourly":{"time":["2024-04-10T14:00"],"pm2_5":[75.0]}}`,
+				"pm25_dest":       `{"hourly":{"time":["2024-04-10T14:00"],"pm2_5":[25.0]}}`,
+			},
+			expectedRecommend: "Strongly Recommended",
+			expectedBasis:     "historical",
+			expectError:       false,
+		},
+		{
+			name: "past date before air-quality history compares temperature only",
+			request: types.TravelRequest{
+				CurrentLocation: types.Location{
+					Lat:  23.8103,
+					Long: 90.4125,
+					Name: "Dhaka",
+				},
+				DestinationDistrictName: "Cox's Bazar",
+				TravelDate:              "2015-07-17",
+			},
+			mockResponses: map[string]string{
+				"archive_current": `{"hourly":{"time":["2015-07-17T14:00"],"temperature_2m":[32.0]}}`,
+				"archive_dest":    `{"hourly":{"time":["2015-07-17T14:00"],"temperature_2m":[32.5]}}`,
+			},
+			expectedRecommend: "Neutral",
+			expectedBasis:     "historical",
+			expectTempOnly:    true,
+			expectError:       false,
+		},
 		{
 			name: "invalid date format returns error",
 			request: types.TravelRequest{
@@ -114,17 +366,17 @@ func TestGetRecommendation(t *testing.T) {
 			errorContains: "invalid travel date format",
 		},
 		{
-			name: "date in past returns error",
+			name: "date before the archive returns error",
 			request: types.TravelRequest{
 				CurrentLocation: types.Location{
 					Lat:  23.8103,
 					Long: 90.4125,
 				},
 				DestinationDistrictName: "Cox's Bazar",
-				TravelDate:              "2020-01-01",
+				TravelDate:              "1939-12-31",
 			},
 			expectError:   true,
-			errorContains: "travel date must be within the next 7 days",
+			errorContains: "travel date must be between 1940-01-01 and 365 days from today",
 		},
 		{
 			name: "date too far in future returns error",
@@ -134,10 +386,10 @@ func TestGetRecommendation(t *testing.T) {
 					Long: 90.4125,
 				},
 				DestinationDistrictName: "Cox's Bazar",
-				TravelDate:              time.Now().AddDate(0, 0, 10).Format("2006-01-02"),
+				TravelDate:              time.Now().AddDate(0, 0, 400).Format("2006-01-02"),
 			},
 			expectError:   true,
-			errorContains: "travel date must be within the next 7 days",
+			errorContains: "travel date must be between 1940-01-01 and 365 days from today",
 		},
 		{
 			name: "invalid district returns error",
@@ -166,13 +418,13 @@ func TestGetRecommendation(t *testing.T) {
 				},
 			}
 
-			service := NewTravelService(districts)
+			service := NewTravelService(forecast.NewOpenMeteo(nil), districts)
 
 			// Replace HTTP client with mock only for success cases
 			if !tt.expectError || tt.errorContains == "destination district not found" {
-				service.httpClient = &http.Client{
+				service.provider = forecast.NewOpenMeteo(&http.Client{
 					Transport: &mockTransport{responses: tt.mockResponses},
-				}
+				})
 			}
 
 			// Call the method
@@ -208,6 +460,14 @@ func TestGetRecommendation(t *testing.T) {
 				t.Error("expected non-empty reason")
 			}
 
+			if tt.expectedUVCategory != "" && result.DestinationWeather.UVCategory != tt.expectedUVCategory {
+				t.Errorf("expected destination UV category '%s', got '%s'", tt.expectedUVCategory, result.DestinationWeather.UVCategory)
+			}
+
+			if tt.expectNoUV && result.DestinationWeather.UVCategory != "" {
+				t.Errorf("expected no UV category without UV data, got '%s'", result.DestinationWeather.UVCategory)
+			}
+
 			if result.CurrentWeather.Name == "" {
 				t.Error("expected non-empty current weather name")
 			}
@@ -215,51 +475,1277 @@ func TestGetRecommendation(t *testing.T) {
 			if result.DestinationWeather.Name == "" {
 				t.Error("expected non-empty destination weather name")
 			}
+
+			if result.Confidence.Level == "" {
+				t.Error("expected a forecast confidence level")
+			}
+
+			expectedBasis := tt.expectedBasis
+			if expectedBasis == "" {
+				expectedBasis = "forecast"
+			}
+			if result.Basis != expectedBasis {
+				t.Errorf("expected basis '%s', got '%s'", expectedBasis, result.Basis)
+			}
+
+			if result.TemperatureOnly != tt.expectTempOnly {
+				t.Errorf("expected temperature_only %v, got %v", tt.expectTempOnly, result.TemperatureOnly)
+			}
+
+			if tt.expectTempOnly {
+				if len(result.PollutantDifferences) != 0 {
+					t.Errorf("expected no pollutant differences, got %v", result.PollutantDifferences)
+				}
+				if result.HealthAdvisory.Level != "unavailable" {
+					t.Errorf("expected unavailable health advisory, got '%s'", result.HealthAdvisory.Level)
+				}
+				if expectedBasis == "forecast" && result.Confidence.Range != "extended" {
+					t.Errorf("expected extended forecast range, got '%s'", result.Confidence.Range)
+				}
+			}
+
+			if expectedBasis == "historical" && result.Confidence.Level != "high" {
+				t.Errorf("expected high confidence for observed data, got '%s'", result.Confidence.Level)
+			}
+
+			if expectedBasis == "climatology" {
+				if result.DestinationWeather.Temp2PM != 28.0 || result.CurrentWeather.Temp2PM != 35.5 {
+					t.Errorf("expected climate normal temps 35.5 and 28.0, got %.2f and %.2f", result.CurrentWeather.Temp2PM, result.DestinationWeather.Temp2PM)
+				}
+				if result.DestinationWeather.TempEnsemble != nil {
+					t.Error("expected no ensemble stats beyond the forecast range")
+				}
+			}
+
+			if tt.request.Ensemble && expectedBasis == "forecast" {
+				if result.DestinationWeather.TempEnsemble == nil || result.DestinationWeather.TempEnsemble.Members != 3 {
+					t.Errorf("expected destination ensemble stats over 3 members, got %+v", result.DestinationWeather.TempEnsemble)
+				}
+				if result.Confidence.Level != "high" {
+					t.Errorf("expected high confidence for agreeing members tomorrow, got '%s'", result.Confidence.Level)
+				}
+			}
+
+			expectedProfile := tt.request.TravelerProfile
+			if expectedProfile == "" {
+				expectedProfile = "general"
+			}
+			if result.TravelerProfile.Name != expectedProfile {
+				t.Errorf("expected traveler profile '%s', got '%s'", expectedProfile, result.TravelerProfile.Name)
+			}
+			if result.TravelerProfile.AppliedRules == nil {
+				t.Error("expected applied rules to be an empty list rather than nil")
+			}
+
+			if !tt.expectTempOnly && (result.DestinationWeather.AQI.USEPA.Category == "" || result.DestinationWeather.AQI.BangladeshDoE.Category == "") {
+				t.Error("expected destination AQI categories for both standards")
+			}
 		})
 	}
 }
 
-func TestGenerateReason(t *testing.T) {
-	s := &TravelService{}
+func TestClimateNormals(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	transport := &mockTransport{responses: map[string]string{
+		"archive_current": `{"hourly":{"time":["2025-03-07T14:00","2025-03-20T14:00","2016-03-13T14:00"],"temperature_2m":[30.0,45.0,32.0]}}`,
+		"pm25_current":    `{"hourly":{"time":["2024-03-10T14:00"],"pm2_5":[60.0]}}`,
+	}}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{Transport: transport}), nil)
 
-	tests := []struct {
-		name       string
-		isCooler   bool
-		isCleaner  bool
-		tempDiff   float64
-		pm25Diff   float64
-		destName   string
-		shouldContain []string
-	}{
-		{
-			name:      "cooler and cleaner with significant differences",
-			isCooler:  true,
-			isCleaner: true,
-			tempDiff:  5.5,
-			pm25Diff:  20.0,
-			destName:  "Cox's Bazar",
-			shouldContain: []string{"Cox's Bazar", "cooler", "better air quality"},
-		},
-		{
-			name:      "hotter and worse air quality",
-			isCooler:  false,
-			isCleaner: false,
-			tempDiff:  -4.0,
-			pm25Diff:  -18.0,
-			destName:  "Dhaka",
-			shouldContain: []string{"Dhaka", "hotter", "worse air quality"},
-		},
+	weather, err := service.fetchClimateNormals(context.Background(), 23.8103, 90.4125, day.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			reason := s.generateReason(tt.isCooler, tt.isCleaner, tt.tempDiff, tt.pm25Diff, tt.destName)
+	// Only the days within the window count, from the oldest year to last year
+	if weather.Temp2PM != 31 || weather.PM25 != 60 {
+		t.Errorf("expected a 31°C normal with 60 µg/m³ PM2.5, got %.2f°C and %.2f", weather.Temp2PM, weather.PM25)
+	}
+	if weather.UVIndex != nil || weather.UVCategory != "" {
+		t.Errorf("expected no UV in climate normals, got %v (%q)", weather.UVIndex, weather.UVCategory)
+	}
+	if transport.requested["archive_current"] != 1 || transport.requested["pm25_current"] != 1 {
+		t.Errorf("expected one ranged request per source, got %v", transport.requested)
+	}
 
-			for _, substr := range tt.shouldContain {
-				if !strings.Contains(reason, substr) {
-					t.Errorf("expected reason to contain '%s', got: %s", substr, reason)
-				}
-			}
+	q := climateQuery(day, climateYears)
+	if q.StartDate != "2016-03-07" || q.EndDate != "2025-03-13" {
+		t.Errorf("expected 2016-03-07 to 2025-03-13, got %s to %s", q.StartDate, q.EndDate)
+	}
+
+	t.Run("almost a year ahead", func(t *testing.T) {
+		// Last year's window isn't archived yet, so the ten years end two years back
+		day := today().AddDate(0, 0, 364)
+		q := climateQuery(day, climateYears)
+		archived := today().AddDate(0, 0, -archiveLagDays).Format("2006-01-02")
+		if q.EndDate > archived {
+			t.Errorf("expected the query to end by %s, got %s", archived, q.EndDate)
+		}
+		if start := day.AddDate(-11, 0, -climateWindowDays).Format("2006-01-02"); q.StartDate != start {
+			t.Errorf("expected ten years from %s, got %s", start, q.StartDate)
+		}
+
+		twoYearsAgo := day.AddDate(-2, 0, 0).Format("2006-01-02")
+		lastYear := day.AddDate(-1, 0, 0).Format("2006-01-02")
+		times := []string{lastYear + "T14:00", twoYearsAgo + "T14:00"}
+		if indices := climateIndices(times, day, climateYears); len(indices) != 1 || indices[0] != 1 {
+			t.Errorf("expected only the sample from two years ago, got %v", indices)
+		}
+	})
+}
+
+func TestRouteSampling(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	times := `["` + tomorrow + `T14:00"]`
+
+	// The road crosses a heat belt between two milder ends
+	districts := []types.District{{ID: "1", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832}}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: map[string]string{
+			"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0]}}`,
+			"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[28.0]}}`,
+			"temp_other":   `{"hourly":{"time":` + times + `,"temperature_2m":[41.5]}}`,
+			"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0]}}`,
+			"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[20.0]}}`,
+			"pm25_other":   `{"hourly":{"time":` + times + `,"pm2_5":[40.0]}}`,
+		}},
+	}), districts)
+
+	req := types.TravelRequest{
+		CurrentLocation:         types.Location{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+		DestinationDistrictName: "Cox's Bazar",
+		TravelDate:              tomorrow,
+		Route:                   &types.RouteRequest{Samples: 4},
+	}
+
+	t.Run("reports the extreme-heat segment", func(t *testing.T) {
+		result, err := service.GetRecommendation(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		route := result.Route
+		if route == nil || len(route.Points) != 4 {
+			t.Fatalf("expected 4 route points, got %+v", route)
+		}
+		if route.Points[0].Temp2PM != 35 || route.Points[1].Temp2PM != 41.5 || route.Points[3].Temp2PM != 28 {
+			t.Errorf("expected origin, sampled and destination temperatures, got %+v", route.Points)
+		}
+		if worst := route.WorstSegment; !worst.ExtremeHeat || worst.MaxTemp2PM != 41.5 || worst.FromKm != 0 {
+			t.Errorf("expected the first extreme-heat segment to be worst, got %+v", worst)
+		}
+		if !strings.Contains(result.Reason, "severe heat wave") {
+			t.Errorf("expected the route heat in the reason, got '%s'", result.Reason)
+		}
+	})
+
+	t.Run("no route unless requested", func(t *testing.T) {
+		r := req
+		r.Route = nil
+		result, err := service.GetRecommendation(context.Background(), r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Route != nil {
+			t.Errorf("expected no route, got %+v", result.Route)
+		}
+	})
+
+	t.Run("too many samples", func(t *testing.T) {
+		r := req
+		r.Route = &types.RouteRequest{Samples: 50}
+		_, err := service.GetRecommendation(context.Background(), r)
+		if err == nil || !strings.Contains(err.Error(), "route samples must be between 2 and 12") {
+			t.Errorf("expected samples error, got %v", err)
+		}
+	})
+
+	t.Run("invalid waypoint", func(t *testing.T) {
+		r := req
+		r.Route = &types.RouteRequest{Polyline: []types.Location{{Lat: 23.3, Long: 90.7}, {Lat: 95, Long: 90.7}}}
+		_, err := service.GetRecommendation(context.Background(), r)
+		if err == nil || !strings.Contains(err.Error(), "waypoint 2 must have lat within ±90") {
+			t.Errorf("expected waypoint error, got %v", err)
+		}
+	})
+
+	t.Run("unavailable route keeps the recommendation", func(t *testing.T) {
+		// No data for the points between the ends
+		service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+			Transport: &mockTransport{responses: map[string]string{
+				"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0]}}`,
+				"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[28.0]}}`,
+				"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0]}}`,
+				"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[20.0]}}`,
+			}},
+		}), districts)
+
+		result, err := service.GetRecommendation(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Recommendation == "" {
+			t.Error("expected a recommendation")
+		}
+		if route := result.Route; route == nil || !route.Unavailable || !strings.Contains(route.Error, "failed to fetch route weather") || route.Points != nil {
+			t.Errorf("expected the route marked unavailable with the error, got %+v", route)
+		}
+	})
+}
+
+func TestGetBestDay(t *testing.T) {
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+	times := `["` + day(0) + `T14:00","` + day(1) + `T14:00","` + day(2) + `T14:00"]`
+
+	districts := []types.District{{ID: "1", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832}}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: map[string]string{
+			"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0,35.0,35.0]}}`,
+			"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[31.0,27.0,33.0]}}`,
+			"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0,60.0,60.0]}}`,
+			"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[40.0,20.0,null]}}`,
+		}},
+	}), districts)
+
+	result, err := service.GetBestDay(context.Background(), types.BestDayRequest{
+		CurrentLocation:         types.Location{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+		DestinationDistrictName: "Cox's Bazar",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Days) != 3 {
+		t.Fatalf("expected 3 scored days, got %d", len(result.Days))
+	}
+	if result.BestDay != day(1) {
+		t.Errorf("expected best day %s, got %s", day(1), result.BestDay)
+	}
+	if result.Recommendation != result.Days[1].Recommendation {
+		t.Errorf("expected best-day verdict '%s', got '%s'", result.Days[1].Recommendation, result.Recommendation)
+	}
+	if !result.Days[2].TemperatureOnly || result.Days[0].TemperatureOnly {
+		t.Error("expected only the day without PM2.5 to be temperature-only")
+	}
+
+	if _, err := service.GetBestDay(context.Background(), types.BestDayRequest{DestinationDistrictName: "Nowhere"}); err == nil {
+		t.Error("expected error for unknown destination")
+	}
+
+	t.Run("ranks within one basis across the air-quality horizon", func(t *testing.T) {
+		times := `["` + day(0) + `T14:00","` + day(1) + `T14:00","` + day(2) + `T14:00","` + day(3) + `T14:00"]`
+		aqTimes := `["` + day(0) + `T14:00","` + day(1) + `T14:00"]`
+		service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+			Transport: &mockTransport{responses: map[string]string{
+				"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0,35.0,35.0,35.0]}}`,
+				"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[34.0,32.0,33.0,28.0]}}`,
+				"pm25_current": `{"hourly":{"time":` + aqTimes + `,"pm2_5":[60.0,60.0]}}`,
+				"pm25_dest":    `{"hourly":{"time":` + aqTimes + `,"pm2_5":[60.0,40.0]}}`,
+			}},
+		}), districts)
+		if err := service.SetHorizonConfig(HorizonConfig{ForecastDays: 4, AirQualityDays: 2}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := service.GetBestDay(context.Background(), types.BestDayRequest{
+			CurrentLocation:         types.Location{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+			DestinationDistrictName: "Cox's Bazar",
 		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Days) != 4 || result.Days[1].TemperatureOnly || !result.Days[3].TemperatureOnly {
+			t.Fatalf("expected two fully compared days then two temperature-only days, got %+v", result.Days)
+		}
+		// The much cooler temperature-only day scores higher but isn't comparable
+		if result.Days[3].Score <= result.Days[1].Score {
+			t.Errorf("expected the temperature-only day to score higher, got %.2f and %.2f", result.Days[3].Score, result.Days[1].Score)
+		}
+		if result.BestDay != day(1) {
+			t.Errorf("expected the best fully compared day %s, got %s", day(1), result.BestDay)
+		}
+		if !strings.Contains(result.Reason, "rank after fully compared days") {
+			t.Errorf("expected the reason to explain the ranking, got '%s'", result.Reason)
+		}
+	})
+}
+
+func TestGetBestDestinations(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	times := `["` + tomorrow + `T14:00"]`
+
+	districts := []types.District{
+		{ID: "1", Name: "Dhaka", Lat: 23.8103, Long: 90.4125},
+		{ID: "2", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832},
+		{ID: "3", Name: "Offline", Lat: 10.0, Long: 10.0}, // mock has no data, skipped
+	}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: map[string]string{
+			"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0]}}`,
+			"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[28.0]}}`,
+			"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0]}}`,
+			"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[20.0]}}`,
+		}},
+	}), districts)
+
+	result, err := service.GetBestDestinations(context.Background(), types.BestDestinationsRequest{
+		CurrentLocation: types.Location{Lat: 23.8103, Long: 90.4125},
+		TravelDate:      tomorrow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Destinations) != 2 {
+		t.Fatalf("expected 2 ranked districts, got %d", len(result.Destinations))
+	}
+	if first := result.Destinations[0]; first.Name != "Cox's Bazar" || first.Rank != 1 {
+		t.Errorf("expected Cox's Bazar ranked 1, got %s ranked %d", first.Name, first.Rank)
+	}
+	if second := result.Destinations[1]; second.Recommendation != "Neutral" || second.TempDifference != 0 {
+		t.Errorf("expected the origin's own district to be Neutral with no temperature difference, got %s (%.2f)", second.Recommendation, second.TempDifference)
+	}
+	if result.CurrentWeather.Name != "Current Location" {
+		t.Errorf("expected default current location name, got '%s'", result.CurrentWeather.Name)
+	}
+	if result.Origin == nil || result.Origin.District.Name != "Dhaka" || !result.Origin.InBangladesh {
+		t.Errorf("expected the origin to be located in Dhaka, got %+v", result.Origin)
+	}
+
+	t.Run("locates the origin", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			location  types.Location
+			warning   bool
+			errSubstr string
+		}{
+			{"near Bangladesh", types.Location{Lat: 23.0437, Long: 88.8206}, true, ""},
+			{"outside Bangladesh", types.Location{Lat: 51.5072, Long: -0.1276}, false, "is outside Bangladesh"},
+			{"invalid coordinates", types.Location{Lat: 123, Long: 90}, false, "lat within ±90"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				origin, err := service.locateOrigin(tt.location)
+				if tt.errSubstr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+						t.Fatalf("expected error containing %q, got %v", tt.errSubstr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if (origin.Warning != "") != tt.warning {
+					t.Errorf("expected warning %v, got %q", tt.warning, origin.Warning)
+				}
+			})
+		}
+
+		t.Run("outside the boundaries", func(t *testing.T) {
+			b, err := geodata.ParseBoundaries(strings.NewReader(`{"features": [{"properties": {"id": "1"}, "geometry": {"type": "Polygon",
+				"coordinates": [[[90, 23.5], [91, 23.5], [91, 24], [90, 24], [90, 23.5]]]}}]}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			bounded := NewTravelService(nil, districts)
+			bounded.SetBoundaries(b)
+
+			// About 50 m outside, within GPS error
+			origin, err := bounded.locateOrigin(types.Location{Lat: 23.75, Long: 89.9995})
+			if err != nil || origin.Method != geodata.MethodBoundary || origin.Warning == "" {
+				t.Errorf("expected a warning just outside the boundary, got %+v (%v)", origin, err)
+			}
+			if _, err := bounded.locateOrigin(types.Location{Lat: 23.75, Long: 89.95}); err == nil || !strings.Contains(err.Error(), "5.1 km from the nearest district") {
+				t.Errorf("expected an origin 5 km outside the boundaries to be rejected, got %v", err)
+			}
+		})
+
+		t.Run("outside the country outline", func(t *testing.T) {
+			b, err := geodata.ParseBoundaries(strings.NewReader(`{"features": [{"properties": {"id": "BGD"}, "geometry": {"type": "Polygon",
+				"coordinates": [[[89, 22], [92, 22], [92, 26], [89, 26], [89, 22]]]}}]}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			outlined := NewTravelService(nil, districts)
+			outlined.SetCountry(b)
+
+			origin, err := outlined.locateOrigin(types.Location{Lat: 23.8103, Long: 90.4125})
+			if err != nil || origin.Method != geodata.MethodNearestCenter || !origin.InBangladesh {
+				t.Errorf("expected an origin inside the outline, got %+v (%v)", origin, err)
+			}
+			if _, err := outlined.locateOrigin(types.Location{Lat: 23.0437, Long: 88.8206}); err == nil || !strings.Contains(err.Error(), "is outside Bangladesh: it is 18.4 km from the border") {
+				t.Errorf("expected an origin across the border to be rejected, got %v", err)
+			}
+			if _, err := outlined.coordinateDestination(types.Location{Lat: 23.0437, Long: 88.8206}); err == nil {
+				t.Error("expected destination coordinates across the border to be rejected")
+			}
+		})
+	})
+
+	_, err = service.GetBestDestinations(context.Background(), types.BestDestinationsRequest{
+		CurrentLocation: types.Location{Lat: 23.8103, Long: 90.4125},
+		TravelDate:      time.Now().AddDate(0, 0, 30).Format("2006-01-02"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "travel date must be within the next 16 days") {
+		t.Errorf("expected forecast window error, got %v", err)
+	}
+
+	t.Run("ranks a district's upazilas", func(t *testing.T) {
+		service.SetUpazilas([]types.Upazila{
+			{ID: "21", DistrictID: "2", Name: "Cox's Bazar Sadar", Lat: 22.3569, Long: 91.7832},
+			{ID: "27", DistrictID: "2", Name: "Teknaf", Lat: 10.0, Long: 10.0}, // mock has no data, skipped
+		})
+
+		result, err := service.GetBestDestinations(context.Background(), types.BestDestinationsRequest{
+			CurrentLocation: types.Location{Lat: 23.8103, Long: 90.4125},
+			TravelDate:      tomorrow,
+			District:        "Cox's Bazar",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.District != "Cox's Bazar" || len(result.Destinations) != 1 {
+			t.Fatalf("expected 1 ranked upazila of Cox's Bazar, got %d of %q", len(result.Destinations), result.District)
+		}
+		if first := result.Destinations[0]; first.ID != "21" || first.Name != "Cox's Bazar Sadar" || first.District != "Cox's Bazar" {
+			t.Errorf("expected Cox's Bazar Sadar, got %+v", first)
+		}
+
+		_, err = service.GetBestDestinations(context.Background(), types.BestDestinationsRequest{
+			CurrentLocation: types.Location{Lat: 23.8103, Long: 90.4125},
+			TravelDate:      tomorrow,
+			District:        "Dhaka",
+		})
+		if err == nil || !strings.Contains(err.Error(), "no upazila data for Dhaka (upazilas are only available in Cox's Bazar)") {
+			t.Errorf("expected missing upazila error naming the covered districts, got %v", err)
+		}
+	})
+}
+
+func TestStartBackgroundRefresh(t *testing.T) {
+	transport := &mockTransport{responses: map[string]string{}}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{Transport: transport}), []types.District{
+		{ID: "2", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832},
+	})
+
+	requests := func() int {
+		transport.mu.Lock()
+		defer transport.mu.Unlock()
+		return transport.requested["temp_dest"]
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.StartBackgroundRefresh(ctx, 10*time.Millisecond)
+
+	// Every tick re-fetches the district's forecast window
+	deadline := time.Now().Add(time.Second)
+	for requests() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	if n := requests(); n < 2 {
+		t.Errorf("expected repeated forecast window fetches, got %d", n)
+	}
+}
+
+func TestCompareDestinations(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	times := `["` + tomorrow + `T14:00"]`
+
+	districts := []types.District{
+		{ID: "1", Name: "Dhaka", Lat: 23.8103, Long: 90.4125},
+		{ID: "2", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832},
+	}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: map[string]string{
+			"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0]}}`,
+			"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[28.0]}}`,
+			"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0]}}`,
+			"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[20.0]}}`,
+		}},
+	}), districts)
+
+	req := types.CompareRequest{
+		CurrentLocation:          types.Location{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+		DestinationDistrictNames: []string{"Dhaka", "Cox's Bazar"},
+		TravelDate:               tomorrow,
+	}
+
+	t.Run("ranks the shortlist", func(t *testing.T) {
+		result, err := service.CompareDestinations(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Destinations) != 2 {
+			t.Fatalf("expected 2 ranked districts, got %d", len(result.Destinations))
+		}
+		if first := result.Destinations[0]; first.Name != "Cox's Bazar" || first.ID != "2" || first.Rank != 1 {
+			t.Errorf("expected Cox's Bazar ranked 1, got %s (%s) ranked %d", first.Name, first.ID, first.Rank)
+		}
+		if first := result.Destinations[0]; first.TempDifference != 7 || first.Basis != BasisForecast {
+			t.Errorf("expected a 7°C forecast drop, got %.2f (%s)", first.TempDifference, first.Basis)
+		}
+		if result.CurrentWeather.Name != "Dhaka" {
+			t.Errorf("expected current location 'Dhaka', got '%s'", result.CurrentWeather.Name)
+		}
+	})
+
+	t.Run("invalid shortlists return errors", func(t *testing.T) {
+		tests := []struct {
+			names         []string
+			errorContains string
+		}{
+			{[]string{"Dhaka"}, "compare between 2 and 5 destination districts"},
+			{[]string{"Dhaka", "Dhaka", "Dhaka", "Dhaka", "Dhaka", "Dhaka"}, "compare between 2 and 5 destination districts"},
+			{[]string{"Dhaka", "Dhaka"}, "destination district listed twice: Dhaka"},
+			{[]string{"Dhaka", "Atlantis"}, "destination district not found: Atlantis"},
+		}
+		for _, tt := range tests {
+			r := req
+			r.DestinationDistrictNames = tt.names
+			_, err := service.CompareDestinations(context.Background(), r)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		}
+	})
+}
+
+func TestGetGroupTrip(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	times := `["` + tomorrow + `T14:00"]`
+
+	districts := []types.District{
+		{ID: "1", Name: "Dhaka", Lat: 23.8103, Long: 90.4125},
+		{ID: "2", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832},
+	}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: map[string]string{
+			"temp_current": `{"hourly":{"time":` + times + `,"temperature_2m":[35.0]}}`,
+			"temp_dest":    `{"hourly":{"time":` + times + `,"temperature_2m":[28.0]}}`,
+			"pm25_current": `{"hourly":{"time":` + times + `,"pm2_5":[60.0]}}`,
+			"pm25_dest":    `{"hourly":{"time":` + times + `,"pm2_5":[20.0]}}`,
+		}},
+	}), districts)
+
+	req := types.GroupTripRequest{
+		Origins: []types.Location{
+			{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+			{Lat: 22.3569, Long: 91.7832},
+		},
+		DestinationDistrictNames: []string{"Dhaka", "Cox's Bazar"},
+		TravelDate:               tomorrow,
+	}
+
+	for _, optimizeFor := range []string{"", OptimizeWorstOff} {
+		t.Run("optimize for "+optimizeFor, func(t *testing.T) {
+			r := req
+			r.OptimizeFor = optimizeFor
+			result, err := service.GetGroupTrip(context.Background(), r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.BestDestination != "Cox's Bazar" {
+				t.Errorf("expected Cox's Bazar, got %s", result.BestDestination)
+			}
+			best := result.Destinations[0]
+			if len(best.Travelers) != 2 || best.WorstOffOrigin != "Origin 2" || best.WorstScore != 50 {
+				t.Errorf("expected the unnamed local origin to be worst off at 50, got %s at %.2f", best.WorstOffOrigin, best.WorstScore)
+			}
+			if optimizeFor == OptimizeWorstOff && best.Score != best.WorstScore {
+				t.Errorf("expected worst-off score %.2f, got %.2f", best.WorstScore, best.Score)
+			}
+			if optimizeFor == "" && (result.OptimizeFor != OptimizeAverage || best.Score != best.AvgScore) {
+				t.Errorf("expected average score %.2f by default, got %.2f (%s)", best.AvgScore, best.Score, result.OptimizeFor)
+			}
+		})
+	}
+
+	t.Run("invalid group trips return errors", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			modify        func(r *types.GroupTripRequest)
+			errorContains string
+		}{
+			{"one origin", func(r *types.GroupTripRequest) { r.Origins = r.Origins[:1] }, "between 2 and 10 origins"},
+			{"no destinations", func(r *types.GroupTripRequest) { r.DestinationDistrictNames = nil }, "between 1 and 10 destination districts"},
+			{"unknown destination", func(r *types.GroupTripRequest) { r.DestinationDistrictNames = []string{"Atlantis"} }, "destination district not found: Atlantis"},
+			{"unknown strategy", func(r *types.GroupTripRequest) { r.OptimizeFor = "median" }, "unknown optimize_for"},
+		}
+		for _, tt := range tests {
+			r := req
+			tt.modify(&r)
+			_, err := service.GetGroupTrip(context.Background(), r)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("%s: expected error containing '%s', got %v", tt.name, tt.errorContains, err)
+			}
+		}
+	})
+}
+
+func TestGetTripRecommendation(t *testing.T) {
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+	temps := func(date string, temp float64) string {
+		return fmt.Sprintf(`{"hourly":{"time":["%sT14:00"],"temperature_2m":[%.1f]}}`, date, temp)
+	}
+
+	districts := []types.District{{ID: "1", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832}}
+	responses := map[string]string{
+		"temp_current":        temps(day(1), 35),
+		"temp_dest":           temps(day(1), 28),
+		"temp_dest@" + day(2): temps(day(2), 41),
+		"pm25_current":        `{"hourly":{"time":["` + day(1) + `T14:00"],"pm2_5":[60.0]}}`,
+		"pm25_dest":           `{"hourly":{"time":["` + day(1) + `T14:00"],"pm2_5":[20.0]}}`,
+	}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: responses},
+	}), districts)
+
+	req := types.TravelRequest{
+		CurrentLocation:         types.Location{Lat: 23.8103, Long: 90.4125, Name: "Dhaka"},
+		DestinationDistrictName: "Cox's Bazar",
+		StartDate:               day(1),
+		EndDate:                 day(3),
+	}
+
+	t.Run("one extreme-heat day holds back the trip", func(t *testing.T) {
+		result, err := service.GetTripRecommendation(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Days) != 3 || result.Summary.Days != 3 {
+			t.Fatalf("expected 3 days, got %d", len(result.Days))
+		}
+		if result.Days[1].TravelDate != day(2) || result.Days[1].DestinationWeather.Temp2PM != 41 {
+			t.Errorf("expected day 2 at 41°C, got %s at %.1f°C", result.Days[1].TravelDate, result.Days[1].DestinationWeather.Temp2PM)
+		}
+		if result.Summary.WorstDay != day(2) {
+			t.Errorf("expected worst day %s, got %s", day(2), result.Summary.WorstDay)
+		}
+		if len(result.Summary.ExtremeHeatDays) != 1 || result.Summary.ExtremeHeatDays[0] != day(2) {
+			t.Errorf("expected extreme heat on %s, got %v", day(2), result.Summary.ExtremeHeatDays)
+		}
+		if result.Summary.AvgScore < 60 {
+			t.Errorf("expected the average day to look good, got %.2f", result.Summary.AvgScore)
+		}
+		if result.Recommendation != "Not Recommended" {
+			t.Errorf("expected 'Not Recommended', got '%s' (score %.2f)", result.Recommendation, result.Score)
+		}
+		if !strings.Contains(result.Reason, "severe heat wave") {
+			t.Errorf("expected heat-wave reason, got '%s'", result.Reason)
+		}
+	})
+
+	t.Run("days beyond the air-quality horizon are scored apart", func(t *testing.T) {
+		// Day 1 is fully compared; days 2 and 3 are temperature-only and would
+		// otherwise be the best and worst days
+		responses := map[string]string{
+			"temp_current":           temps(day(1), 35),
+			"temp_current@" + day(2): temps(day(2), 35),
+			"temp_current@" + day(3): temps(day(3), 35),
+			"temp_dest":              temps(day(1), 33),
+			"temp_dest@" + day(2):    temps(day(2), 25),
+			"temp_dest@" + day(3):    temps(day(3), 37),
+			"pm25_current":           `{"hourly":{"time":["` + day(1) + `T14:00"],"pm2_5":[40.0]}}`,
+			"pm25_dest":              `{"hourly":{"time":["` + day(1) + `T14:00"],"pm2_5":[40.0]}}`,
+		}
+		service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+			Transport: &mockTransport{responses: responses},
+		}), districts)
+		if err := service.SetHorizonConfig(HorizonConfig{ForecastDays: 16, AirQualityDays: 2}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := service.GetTripRecommendation(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		summary := result.Summary
+		if summary.TemperatureOnlyDays != 2 || result.Days[0].TemperatureOnly || !result.Days[1].TemperatureOnly {
+			t.Fatalf("expected days 2 and 3 to be temperature-only, got %d", summary.TemperatureOnlyDays)
+		}
+		first := result.Days[0].Score
+		if summary.BestDay != day(1) || summary.WorstDay != day(1) || summary.AvgScore != first {
+			t.Errorf("expected day 1 (%.2f) to be best, worst and the average, got best %s, worst %s, average %.2f",
+				first, summary.BestDay, summary.WorstDay, summary.AvgScore)
+		}
+		expected := round2((result.Days[1].Score + result.Days[2].Score) / 2)
+		if summary.TemperatureOnlyAvgScore == nil || *summary.TemperatureOnlyAvgScore != expected {
+			t.Errorf("expected a temperature-only average of %.2f, got %v", expected, summary.TemperatureOnlyAvgScore)
+		}
+		if result.Score != first {
+			t.Errorf("expected the trip to score like day 1 (%.2f), got %.2f", first, result.Score)
+		}
+	})
+
+	t.Run("invalid ranges return errors", func(t *testing.T) {
+		tests := []struct {
+			start, end    string
+			errorContains string
+		}{
+			{day(3), day(1), "end date must not be before start date"},
+			{day(1), day(20), "trips can be at most 14 days long"},
+			{"bad", day(1), "invalid start date format"},
+		}
+		for _, tt := range tests {
+			r := req
+			r.StartDate, r.EndDate = tt.start, tt.end
+			_, err := service.GetTripRecommendation(context.Background(), r)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		}
+	})
+}
+
+func TestGetItinerary(t *testing.T) {
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+	temps := func(date string, temp float64) string {
+		return fmt.Sprintf(`{"hourly":{"time":["%sT14:00"],"temperature_2m":[%.1f]}}`, date, temp)
+	}
+	pm25 := func(date string, value float64) string {
+		return fmt.Sprintf(`{"hourly":{"time":["%sT14:00"],"pm2_5":[%.1f]}}`, date, value)
+	}
+
+	// Dhaka stays hot and polluted, Cox's Bazar stays cool and clean
+	responses := map[string]string{}
+	for _, d := range []string{day(1), day(2)} {
+		responses["temp_current@"+d] = temps(d, 35)
+		responses["temp_dest@"+d] = temps(d, 28)
+		responses["pm25_current@"+d] = pm25(d, 60)
+		responses["pm25_dest@"+d] = pm25(d, 20)
+	}
+
+	districts := []types.District{
+		{ID: "1", Name: "Dhaka", Lat: 23.8103, Long: 90.4125},
+		{ID: "2", Name: "Cox's Bazar", Lat: 22.3569, Long: 91.7832},
+	}
+	service := NewTravelService(forecast.NewOpenMeteo(&http.Client{
+		Transport: &mockTransport{responses: responses},
+	}), districts)
+
+	req := types.ItineraryRequest{
+		CurrentLocation: types.Location{Lat: 23.8103, Long: 90.4125, Name: "Home"},
+		Stops: []types.ItineraryStop{
+			{DistrictName: "Cox's Bazar", Date: day(1)},
+			{DistrictName: "Dhaka", Date: day(2)},
+		},
+	}
+
+	t.Run("flags a sharply worse leg and suggests a reorder", func(t *testing.T) {
+		result, err := service.GetItinerary(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Stops) != 2 || len(result.Legs) != 2 {
+			t.Fatalf("expected 2 stops and 2 legs, got %d and %d", len(result.Stops), len(result.Legs))
+		}
+		if leg := result.Legs[0]; leg.From != "Home" || leg.To != "Cox's Bazar" || leg.SharpWorsening {
+			t.Errorf("expected a pleasant first leg from Home, got %+v", leg)
+		}
+		if leg := result.Legs[1]; !leg.SharpWorsening || leg.TempDifference != -7 {
+			t.Errorf("expected Cox's Bazar to Dhaka to worsen sharply by 7°C, got %+v", leg)
+		}
+		if result.SharpLegs != 1 {
+			t.Errorf("expected 1 sharp leg, got %d", result.SharpLegs)
+		}
+
+		suggestion := result.SuggestedOrder
+		if suggestion == nil {
+			t.Fatal("expected a suggested order")
+		}
+		if suggestion.SharpLegs != 0 || suggestion.Stops[0].DistrictName != "Dhaka" || suggestion.Stops[0].Date != day(1) {
+			t.Errorf("expected Dhaka first with no sharp legs, got %+v", suggestion)
+		}
+	})
+
+	t.Run("no suggestion when the order is already best", func(t *testing.T) {
+		r := req
+		r.Stops = []types.ItineraryStop{
+			{DistrictName: "Dhaka", Date: day(1)},
+			{DistrictName: "Cox's Bazar", Date: day(2)},
+		}
+		result, err := service.GetItinerary(context.Background(), r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.SuggestedOrder != nil {
+			t.Errorf("expected no suggestion, got %+v", result.SuggestedOrder)
+		}
+	})
+
+	t.Run("invalid itineraries return errors", func(t *testing.T) {
+		tests := []struct {
+			stops         []types.ItineraryStop
+			errorContains string
+		}{
+			{nil, "itineraries must have between 1 and 5 stops"},
+			{[]types.ItineraryStop{{DistrictName: "Dhaka", Date: day(2)}, {DistrictName: "Dhaka", Date: day(1)}}, "stops must be in date order"},
+			{[]types.ItineraryStop{{DistrictName: "Atlantis", Date: day(1)}}, "stop 1: destination district not found: Atlantis"},
+			{[]types.ItineraryStop{{DistrictName: "Dhaka", Date: day(1)}, {DistrictName: "Dhaka", Date: "bad"}}, "stop 2: invalid travel date format"},
+		}
+		for _, tt := range tests {
+			r := req
+			r.Stops = tt.stops
+			_, err := service.GetItinerary(context.Background(), r)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		}
+	})
+}
+
+func TestPermutations(t *testing.T) {
+	perms := permutations(4)
+	if len(perms) != 24 {
+		t.Fatalf("expected 24 orders, got %d", len(perms))
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range perms {
+		seen[fmt.Sprint(p)] = true
+	}
+	if len(seen) != 24 {
+		t.Errorf("expected 24 distinct orders, got %d", len(seen))
+	}
+}
+
+func TestResolveDestination(t *testing.T) {
+	service := NewTravelService(nil, []types.District{
+		{ID: "1", Name: "Dhaka", BnName: "ঢাকা", Lat: 23.7115, Long: 90.4111},
+		{ID: "41", Name: "Cox's Bazar", BnName: "কক্সবাজার", Lat: 21.4272, Long: 92.0058},
+		{ID: "43", Name: "Chattogram", BnName: "চট্টগ্রাম", Lat: 22.3351, Long: 91.8341},
+		{ID: "20", Name: "Barishal", Lat: 22.7010, Long: 90.3535},
+	})
+	service.SetUpazilas([]types.Upazila{
+		{ID: "27", DistrictID: "41", Name: "Teknaf", BnName: "টেকনাফ", Lat: 20.8623, Long: 92.3058},
+		{ID: "5", DistrictID: "1", Name: "Companiganj", Lat: 23.8, Long: 90.4},
+		{ID: "9", DistrictID: "43", Name: "Companiganj", Lat: 22.4, Long: 91.8},
+	})
+
+	tests := []struct {
+		name          string
+		id            string
+		district      string
+		upazila       string
+		location      *types.Location
+		expectedName  string
+		expectedIn    string // ID of an upazila's district
+		errorContains string
+	}{
+		{name: "English name ignores case", district: "cox's bazar", expectedName: "Cox's Bazar"},
+		{name: "Bangla name", district: "কক্সবাজার", expectedName: "Cox's Bazar"},
+		{name: "district ID", id: "1", expectedName: "Dhaka"},
+		{name: "ID and name agree", id: "41", district: "Cox's Bazar", expectedName: "Cox's Bazar"},
+		{name: "coordinates", location: &types.Location{Lat: 24.8949, Long: 91.8687, Name: "Sylhet tea garden"}, expectedName: "Sylhet tea garden"},
+		{name: "unnamed coordinates", location: &types.Location{Lat: 24.8949, Long: 91.8687}, expectedName: "Destination (24.8949, 91.8687)"},
+		{name: "nothing set", errorContains: "destination is required"},
+		{name: "unknown ID", id: "99", errorContains: `destination_district_id "99" matches no district`},
+		{
+			name:          "both identifiers fail",
+			id:            "99",
+			district:      "Atlantis",
+			errorContains: `destination_district_id "99" matches no district; destination_district "Atlantis" matches no English or Bangla district name`,
+		},
+		{name: "historic spelling", district: "Dacca", expectedName: "Dhaka"},
+		{name: "typo suggests a district", district: "Coxs Bazzar", errorContains: `matches no English or Bangla district name (did you mean Cox's Bazar?)`},
+		{name: "ID and name disagree", id: "1", district: "Cox's Bazar", errorContains: "are different districts"},
+		{name: "upazila", upazila: "টেকনাফ", expectedName: "Teknaf", expectedIn: "41"},
+		{name: "upazila by ID within its district", id: "41", upazila: "27", expectedName: "Teknaf", expectedIn: "41"},
+		{name: "district picks between upazilas", district: "Chittagong", upazila: "Companiganj", expectedName: "Companiganj", expectedIn: "43"},
+		{name: "upazila in several districts", upazila: "Companiganj", errorContains: `destination_upazila "Companiganj" is in several districts: Companiganj (Dhaka), Companiganj (Chattogram)`},
+		{name: "upazila outside the district", district: "Dhaka", upazila: "Teknaf", errorContains: `Dhaka has no upazila "Teknaf"`},
+		{name: "unknown upazila", upazila: "Atlantis", errorContains: "destination upazila not found: Atlantis (upazilas are only available in Dhaka, Cox's Bazar, Chattogram)"},
+		{name: "district without upazila data", district: "Barishal", upazila: "Bakerganj", errorContains: "no upazila data for Barishal (upazilas are only available in"},
+		{name: "coordinates with an upazila", upazila: "Teknaf", location: &types.Location{Lat: 20.9, Long: 92.3}, errorContains: "not both"},
+		{name: "coordinates with a district", district: "Dhaka", location: &types.Location{Lat: 23.7, Long: 90.4}, errorContains: "not both"},
+		{name: "invalid coordinates", location: &types.Location{Lat: 123, Long: 90.4}, errorContains: "destination_location must have lat within ±90"},
+		{name: "coordinates near the border", location: &types.Location{Lat: 23.0437, Long: 88.8206}, expectedName: "Destination (23.0437, 88.8206)"},
+		{name: "coordinates outside the region", location: &types.Location{Lat: 51.5072, Long: -0.1276}, errorContains: "destination_location (51.5072, -0.1276) is outside Bangladesh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := service.resolveDestination(tt.id, tt.district, tt.upazila, tt.location)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.Name != tt.expectedName {
+				t.Errorf("expected '%s', got '%s'", tt.expectedName, d.Name)
+			}
+			if tt.expectedIn != "" {
+				parent, _ := service.districts.ByID(tt.expectedIn)
+				if d.ID != tt.expectedIn || d.DistrictName != parent.Name {
+					t.Errorf("expected to be in %s (%s), got %q (%q)", parent.Name, tt.expectedIn, d.DistrictName, d.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateReason(t *testing.T) {
+	s := &TravelService{}
+
+	tests := []struct {
+		name          string
+		isCooler      bool
+		isCleaner     bool
+		tempDiff      float64
+		pm25Diff      float64
+		destUV        float64
+		destName      string
+		shouldContain []string
+	}{
+		{
+			name:          "cooler and cleaner with significant differences",
+			isCooler:      true,
+			isCleaner:     true,
+			tempDiff:      5.5,
+			pm25Diff:      20.0,
+			destName:      "Cox's Bazar",
+			shouldContain: []string{"Cox's Bazar", "cooler", "better air quality"},
+		},
+		{
+			name:          "hotter and worse air quality",
+			isCooler:      false,
+			isCleaner:     false,
+			tempDiff:      -4.0,
+			pm25Diff:      -18.0,
+			destName:      "Dhaka",
+			shouldContain: []string{"Dhaka", "hotter", "worse air quality"},
+		},
+		{
+			name:          "high destination UV adds sun-protection advice",
+			isCooler:      true,
+			isCleaner:     true,
+			tempDiff:      2.0,
+			pm25Diff:      10.0,
+			destUV:        9.2,
+			destName:      "Cox's Bazar",
+			shouldContain: []string{"Cox's Bazar", "UV is very high", "sunscreen"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := s.generateReason(tt.isCooler, tt.isCleaner, tt.tempDiff, tt.pm25Diff, tt.destUV, tt.destName)
+
+			for _, substr := range tt.shouldContain {
+				if !strings.Contains(reason, substr) {
+					t.Errorf("expected reason to contain '%s', got: %s", substr, reason)
+				}
+			}
+		})
+	}
+}
+
+func TestHorizonConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  HorizonConfig
+		wantErr bool
+	}{
+		{"default", DefaultHorizonConfig, false},
+		{"seven days", HorizonConfig{ForecastDays: 7, AirQualityDays: 5}, false},
+		{"beyond provider limit", HorizonConfig{ForecastDays: 17, AirQualityDays: 5}, true},
+		{"air quality beyond provider limit", HorizonConfig{ForecastDays: 16, AirQualityDays: 8}, true},
+		{"air quality longer than forecast", HorizonConfig{ForecastDays: 3, AirQualityDays: 5}, true},
+		{"zero forecast days", HorizonConfig{ForecastDays: 0, AirQualityDays: 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewTravelService(nil, nil).SetHorizonConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("parses from text", func(t *testing.T) {
+		cfg, err := ParseHorizonConfig(" 10 ", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.ForecastDays != 10 || cfg.AirQualityDays != DefaultHorizonConfig.AirQualityDays {
+			t.Errorf("expected 10 forecast days and the default air-quality horizon, got %+v", cfg)
+		}
+
+		for _, text := range [][2]string{{"17", ""}, {"", "8"}, {"3", ""}, {"ten", ""}, {"", "0"}} {
+			if _, err := ParseHorizonConfig(text[0], text[1]); err == nil {
+				t.Errorf("expected an error for forecast %q and air quality %q", text[0], text[1])
+			}
+		}
+	})
+
+	t.Run("today is the date in Bangladesh", func(t *testing.T) {
+		expected := time.Now().UTC().Add(6 * time.Hour).Format("2006-01-02")
+		if got := today(); got.Format("2006-01-02") != expected || got.Location() != time.UTC || got.Hour() != 0 {
+			t.Errorf("expected %s at midnight UTC, got %s", expected, got)
+		}
+	})
+}
+
+func TestScoringConfig(t *testing.T) {
+	cfg := DefaultScoringConfig
+	general, _ := LookupProfile("general")
+	elderly, _ := LookupProfile("elderly")
+
+	tests := []struct {
+		name            string
+		tempDiff        float64
+		aqDiff          float64
+		uvDiff          float64
+		withoutAQ       bool
+		withoutUV       bool
+		profile         TravelerProfile
+		expectedVerdict string
+		expectedFactors int
+	}{
+		{
+			name:            "tiny temperature difference stays neutral",
+			tempDiff:        -0.01,
+			aqDiff:          0,
+			profile:         general,
+			expectedVerdict: "Neutral",
+		},
+		{
+			name:            "moderately cooler and cleaner is recommended",
+			tempDiff:        2.5,
+			aqDiff:          10,
+			profile:         general,
+			expectedVerdict: "Recommended",
+		},
+		{
+			name:            "much hotter and dirtier is strongly discouraged",
+			tempDiff:        -6,
+			aqDiff:          -30,
+			uvDiff:          -3,
+			profile:         general,
+			expectedVerdict: "Strongly Discouraged",
+		},
+		{
+			name:            "same trade-off is neutral for general profile",
+			tempDiff:        -2,
+			aqDiff:          10,
+			profile:         general,
+			expectedVerdict: "Neutral",
+		},
+		{
+			name:            "temperature-only comparison can reach the top grade",
+			tempDiff:        6,
+			withoutAQ:       true,
+			profile:         general,
+			expectedVerdict: "Strongly Recommended",
+			expectedFactors: 2,
+		},
+		{
+			name:            "temperature alone decides without air quality or UV",
+			tempDiff:        -6,
+			withoutAQ:       true,
+			withoutUV:       true,
+			profile:         general,
+			expectedVerdict: "Strongly Discouraged",
+			expectedFactors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, factors := cfg.score(factorDifferences{
+				temp:              tt.tempDiff,
+				airQuality:        tt.aqDiff,
+				uv:                tt.uvDiff,
+				withoutAirQuality: tt.withoutAQ,
+				withoutUV:         tt.withoutUV,
+			}, tt.profile)
+
+			if verdict := cfg.verdict(score); verdict != tt.expectedVerdict {
+				t.Errorf("expected verdict '%s', got '%s' (score %.2f)", tt.expectedVerdict, verdict, score)
+			}
+			if score < 0 || score > 100 {
+				t.Errorf("score %.2f out of range", score)
+			}
+
+			expectedFactors := tt.expectedFactors
+			if expectedFactors == 0 {
+				expectedFactors = 3
+			}
+			if len(factors) != expectedFactors {
+				t.Errorf("expected %d scored factors, got %d", expectedFactors, len(factors))
+			}
+
+			// Contributions must add up to the score around the neutral 50
+			total := 50.0
+			for _, f := range factors {
+				total += f.Points
+			}
+			if diff := total - score; diff > 0.05 || diff < -0.05 {
+				t.Errorf("factor points sum to %.2f, score is %.2f", total, score)
+			}
+		})
+	}
+
+	t.Run("elderly score is lower than general for hotter destination", func(t *testing.T) {
+		generalScore, _ := cfg.score(factorDifferences{temp: -2, airQuality: 10}, general)
+		elderlyScore, _ := cfg.score(factorDifferences{temp: -2, airQuality: 10}, elderly)
+		if elderlyScore >= generalScore {
+			t.Errorf("expected elderly score %.2f below general score %.2f", elderlyScore, generalScore)
+		}
+	})
+
+	t.Run("rejects unordered thresholds", func(t *testing.T) {
+		bad := DefaultScoringConfig
+		bad.Thresholds.Recommended = 90
+		if err := bad.Validate(); err == nil {
+			t.Error("expected validation error")
+		}
+	})
+
+	t.Run("parses from text", func(t *testing.T) {
+		tests := []struct {
+			name                        string
+			weights, scales, thresholds string
+			errSubstr                   string
+		}{
+			{name: "empty keeps the defaults"},
+			{name: "overrides", weights: "temperature=2, uv_index=0", scales: "air_quality=50", thresholds: "strongly_recommended=85"},
+			{name: "unknown factor", weights: "humidity=1", errSubstr: `unknown key "humidity"`},
+			{name: "not a pair", scales: "temperature", errSubstr: "must be key=value"},
+			{name: "not a number", thresholds: "neutral=high", errSubstr: "verdict threshold"},
+			{name: "not finite", weights: "temperature=NaN", errSubstr: "finite"},
+			{name: "invalid config", thresholds: "recommended=90", errSubstr: "strictly decreasing"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				parsed, err := ParseScoringConfig(tt.weights, tt.scales, tt.thresholds)
+				if tt.errSubstr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+						t.Fatalf("expected error containing %q, got %v", tt.errSubstr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				expected := DefaultScoringConfig
+				if tt.weights != "" {
+					expected.TempWeight, expected.UVWeight = 2, 0
+					expected.AirScale = 50
+					expected.Thresholds.StronglyRecommended = 85
+				}
+				if parsed != expected {
+					t.Errorf("expected %+v, got %+v", expected, parsed)
+				}
+			})
+		}
+	})
+}
+
+func TestForecastConfidence(t *testing.T) {
+	tests := []struct {
+		name           string
+		leadDays       int
+		current        map[string]float64
+		dest           map[string]float64
+		ensembleErr    error
+		expectedLevel  string
+		expectedReason string
+	}{
+		{
+			name:          "tomorrow without ensemble is high",
+			leadDays:      1,
+			expectedLevel: "high",
+		},
+		{
+			name:          "a week out without ensemble is medium",
+			leadDays:      7,
+			expectedLevel: "medium",
+		},
+		{
+			name:          "agreeing tight ensemble stays high",
+			leadDays:      1,
+			current:       members(34.0, 34.5, 35.0, 35.5),
+			dest:          members(28.0, 28.5, 29.0, 29.5),
+			expectedLevel: "high",
+		},
+		{
+			name:          "split ensemble a week out is low",
+			leadDays:      7,
+			current:       members(30.0, 31.0, 32.0, 33.0),
+			dest:          members(29.0, 32.0, 31.0, 34.0),
+			expectedLevel: "low",
+		},
+		{
+			// Paired by position, every member would look warmer
+			name:           "a member missing at one location drops its pair",
+			leadDays:       1,
+			current:        members(30.0, 30.2, 30.4, 30.6),
+			dest:           map[string]float64{"member01": 30.1, "member02": 30.3, "member03": 30.5},
+			expectedLevel:  "high",
+			expectedReason: "100% of members show the destination cooler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := forecastConfidence(tt.leadDays, tt.current, tt.dest, tt.ensembleErr)
+			if result.Level != tt.expectedLevel {
+				t.Errorf("expected level '%s', got '%s' (score %.2f)", tt.expectedLevel, result.Level, result.Score)
+			}
+			if len(result.Reasons) == 0 {
+				t.Error("expected confidence reasons")
+			}
+			if tt.expectedReason != "" && !slices.Contains(result.Reasons, tt.expectedReason) {
+				t.Errorf("expected reason %q, got %v", tt.expectedReason, result.Reasons)
+			}
+		})
+	}
+}
+
+// members keys ensemble values as the control run followed by numbered members
+func members(values ...float64) map[string]float64 {
+	m := make(map[string]float64, len(values))
+	for i, v := range values {
+		key := "control"
+		if i > 0 {
+			key = fmt.Sprintf("member%02d", i)
+		}
+		m[key] = v
+	}
+	return m
+}
+
+func TestEnsembleStats(t *testing.T) {
+	stats := ensembleStats([]float64{30, 28, 26, 32, 34})
+
+	if stats.Members != 5 || stats.Mean != 30 || stats.P50 != 30 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.P10 != 26.8 || stats.P90 != 33.2 || stats.Spread != 6.4 {
+		t.Errorf("unexpected percentiles: %+v", stats)
 	}
 }
@@ -0,0 +1,190 @@
+package travel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+const (
+	// maxTripDays is the longest trip compared day by day
+	maxTripDays = 14
+	// extremeHeatTemp2PM is the 2PM temperature treated as a severe heat-wave
+	// day (Bangladesh Meteorological Department: 40°C and above)
+	extremeHeatTemp2PM = 40.0
+)
+
+// GetTripRecommendation compares every day of a multi-day trip and grades the
+// whole stay. The trip score is the average day score, held to at most one
+// verdict band above the worst day so a single bad day can't be averaged away.
+func (s *TravelService) GetTripRecommendation(ctx context.Context, req types.TravelRequest) (*types.TripRecommendation, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date format, use YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date format, use YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	numDays := int(endDate.Sub(startDate).Hours()/24) + 1
+	if numDays > maxTripDays {
+		return nil, fmt.Errorf("trips can be at most %d days long", maxTripDays)
+	}
+
+	type dayResult struct {
+		index          int
+		recommendation *types.TravelRecommendation
+		err            error
+	}
+
+	// Each day is compared exactly like a single-day request
+	results := make(chan dayResult, numDays)
+	for i := 0; i < numDays; i++ {
+		dayReq := req
+		dayReq.TravelDate = startDate.AddDate(0, 0, i).Format("2006-01-02")
+		dayReq.StartDate, dayReq.EndDate = "", ""
+
+		go func(i int) {
+			rec, err := s.GetRecommendation(ctx, dayReq)
+			results <- dayResult{index: i, recommendation: rec, err: err}
+		}(i)
+	}
+
+	days := make([]types.TravelRecommendation, numDays)
+	var errs []error
+	for i := 0; i < numDays; i++ {
+		r := <-results
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		days[r.index] = *r.recommendation
+	}
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	summary := summarizeTrip(days)
+
+	score := summary.AvgScore
+	bandWidth := s.scoring.Thresholds.Recommended - s.scoring.Thresholds.Neutral
+	heldBack := score > summary.WorstScore+bandWidth
+	if heldBack {
+		score = summary.WorstScore + bandWidth
+	}
+	if len(summary.ExtremeHeatDays) > 0 {
+		// Severe heat caps the trip at "Not Recommended" whatever the origin is like
+		score = math.Min(score, s.scoring.Thresholds.Neutral-0.01)
+	}
+	score = round2(score)
+
+	destName := days[0].DestinationWeather.Name
+	tempDiff := summary.AvgTempDifference
+	reason := fmt.Sprintf("Across %d days %s is on average %s.", numDays, destName, describeTempDiff(tempDiff > 0, tempDiff))
+	if len(summary.ExtremeHeatDays) > 0 {
+		reason += fmt.Sprintf(" It reaches %.1f°C or more on %s, a severe heat wave.", extremeHeatTemp2PM, strings.Join(summary.ExtremeHeatDays, ", "))
+	}
+	if heldBack || summary.WorstScore < s.scoring.Thresholds.Neutral {
+		worst := days[summary.worstIndex]
+		reason += fmt.Sprintf(" The worst day is %s (%s): %s", worst.TravelDate, worst.Recommendation, worst.Reason)
+	}
+
+	return &types.TripRecommendation{
+		Recommendation: s.scoring.verdict(score),
+		Score:          score,
+		Reason:         reason,
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		Summary:        summary.TripSummary,
+		Days:           days,
+	}, nil
+}
+
+// tripSummary is the trip summary plus the index of the worst day
+type tripSummary struct {
+	types.TripSummary
+	worstIndex int
+}
+
+// summarizeTrip aggregates per-day comparisons. Pollutant averages only use
+// days with air-quality data. Temperature-only scores don't weigh the same
+// factors, so like ranksAbove the average, best and worst days come from the
+// fully compared days, and temperature-only days are averaged separately. A
+// trip entirely beyond the air-quality horizon is scored on temperature alone.
+func summarizeTrip(days []types.TravelRecommendation) tripSummary {
+	summary := tripSummary{TripSummary: types.TripSummary{
+		Days:            len(days),
+		ExtremeHeatDays: []string{},
+	}}
+
+	scoredTemperatureOnly := true
+	for _, d := range days {
+		if !d.TemperatureOnly {
+			scoredTemperatureOnly = false
+			break
+		}
+	}
+
+	var scoreSum, temperatureOnlyScoreSum, tempDiffSum, destTempSum, destPM25Sum float64
+	best, scoredDays, airQualityDays := -1, 0, 0
+	summary.worstIndex = -1
+	for i, d := range days {
+		tempDiffSum += d.TempDifference
+		destTempSum += d.DestinationWeather.Temp2PM
+
+		if d.TemperatureOnly {
+			temperatureOnlyScoreSum += d.Score
+		}
+		if d.TemperatureOnly == scoredTemperatureOnly {
+			scoreSum += d.Score
+			scoredDays++
+			if summary.worstIndex < 0 || d.Score < days[summary.worstIndex].Score {
+				summary.worstIndex = i
+			}
+			if best < 0 || d.Score > days[best].Score {
+				best = i
+			}
+		}
+
+		if i == 0 || d.DestinationWeather.Temp2PM > summary.MaxDestTemp2PM {
+			summary.MaxDestTemp2PM = d.DestinationWeather.Temp2PM
+		}
+		if d.DestinationWeather.Temp2PM >= extremeHeatTemp2PM {
+			summary.ExtremeHeatDays = append(summary.ExtremeHeatDays, d.TravelDate)
+		}
+
+		if d.TemperatureOnly {
+			summary.TemperatureOnlyDays++
+			continue
+		}
+		airQualityDays++
+		destPM25Sum += d.DestinationWeather.PM25
+		summary.MaxDestPM25 = math.Max(summary.MaxDestPM25, d.DestinationWeather.PM25)
+	}
+
+	n := float64(len(days))
+	summary.AvgScore = round2(scoreSum / float64(scoredDays))
+	if summary.TemperatureOnlyDays > 0 && !scoredTemperatureOnly {
+		avg := round2(temperatureOnlyScoreSum / float64(summary.TemperatureOnlyDays))
+		summary.TemperatureOnlyAvgScore = &avg
+	}
+	summary.AvgTempDifference = round2(tempDiffSum / n)
+	summary.AvgDestTemp2PM = round2(destTempSum / n)
+	if airQualityDays > 0 {
+		summary.AvgDestPM25 = round2(destPM25Sum / float64(airQualityDays))
+	}
+	summary.WorstDay = days[summary.worstIndex].TravelDate
+	summary.WorstScore = days[summary.worstIndex].Score
+	summary.BestDay = days[best].TravelDate
+	summary.BestScore = days[best].Score
+
+	return summary
+}
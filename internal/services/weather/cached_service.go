@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/types"
 )
 
@@ -19,15 +20,48 @@ type CachedWeatherService struct {
 }
 
 // NewCachedWeatherService creates a cached weather service
-func NewCachedWeatherService(districts []types.District, cacheTTL time.Duration) *CachedWeatherService {
+func NewCachedWeatherService(provider forecast.Provider, districts []types.District, cacheTTL time.Duration) *CachedWeatherService {
 	return &CachedWeatherService{
-		service:  NewWeatherService(districts),
+		service:  NewWeatherService(provider, districts),
 		cacheTTL: cacheTTL,
 	}
 }
 
-// GetTopCoolestAndCleanest returns cached data or fetches fresh data
+// SetWindow sets the date ranges the service fetches; see
+// WeatherService.SetWindow
+func (c *CachedWeatherService) SetWindow(window func() (forecast.Query, forecast.Query)) {
+	c.service.SetWindow(window)
+}
+
+// GetTopCoolestAndCleanest returns the top 10 districts using the default ranking
 func (c *CachedWeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]types.DistrictWeather, error) {
+	return c.GetTopDestinations(ctx, DefaultRankOptions)
+}
+
+// GetTopDestinations ranks the cached district data with the given options
+func (c *CachedWeatherService) GetTopDestinations(ctx context.Context, opts RankOptions) ([]types.DistrictWeather, error) {
+	districts, err := c.GetAllDistrictWeather(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.service.rankDistrictsBy(districts, opts), nil
+}
+
+// GetDivisionSummaries summarizes the cached district data per division, in
+// the order divisions are given. Each division's best district is chosen with
+// the given options.
+func (c *CachedWeatherService) GetDivisionSummaries(ctx context.Context, divisions []types.Division, opts RankOptions) ([]types.DivisionSummary, error) {
+	districts, err := c.GetAllDistrictWeather(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.service.summarizeDivisions(districts, divisions, opts), nil
+}
+
+// GetAllDistrictWeather returns cached data for every district or fetches fresh data
+func (c *CachedWeatherService) GetAllDistrictWeather(ctx context.Context) ([]types.DistrictWeather, error) {
 	c.mu.RLock()
 	if c.cache != nil && time.Since(c.lastUpdated) < c.cacheTTL {
 		result := make([]types.DistrictWeather, len(c.cache))
@@ -62,7 +96,7 @@ func (c *CachedWeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]
 	c.mu.Unlock()
 
 	// Fetch fresh data
-	data, err := c.service.GetTopCoolestAndCleanest(ctx)
+	data, err := c.service.GetAllDistrictWeather(ctx)
 
 	c.mu.Lock()
 	c.updating = false
@@ -72,12 +106,37 @@ func (c *CachedWeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]
 	}
 	c.mu.Unlock()
 
-	return data, err
+	if err != nil {
+		return nil, err
+	}
+
+	// Hand out a copy so ranking can't reorder the cached slice
+	result := make([]types.DistrictWeather, len(data))
+	copy(result, data)
+
+	return result, nil
+}
+
+// CachedDistrict returns the latest cached data for one district and when it
+// was fetched. It never fetches: ok is false until the cache has been filled,
+// and stale data is returned as is.
+func (c *CachedWeatherService) CachedDistrict(id string) (weather types.DistrictWeather, updatedAt time.Time, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, d := range c.cache {
+		if d.ID == id {
+			// Ranks only mean something within a ranked list
+			d.Rank = 0
+			return d, c.lastUpdated, true
+		}
+	}
+	return types.DistrictWeather{}, time.Time{}, false
 }
 
 // WarmCache pre-fetches data on startup
 func (c *CachedWeatherService) WarmCache(ctx context.Context) error {
-	_, err := c.GetTopCoolestAndCleanest(ctx)
+	_, err := c.GetAllDistrictWeather(ctx)
 	return err
 }
 
@@ -94,7 +153,7 @@ func (c *CachedWeatherService) StartBackgroundRefresh(ctx context.Context) {
 			case <-ticker.C:
 				// Background refresh - don't block on errors
 				refreshCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-				c.GetTopCoolestAndCleanest(refreshCtx)
+				c.GetAllDistrictWeather(refreshCtx)
 				cancel()
 			}
 		}
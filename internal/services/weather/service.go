@@ -2,47 +2,94 @@ package weather
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
-	"net/http"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/aqi"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+	"github.com/shuv1824/recommender/internal/utils/uvindex"
 )
 
 type WeatherService struct {
-	httpClient *http.Client
-	districts  []types.District
+	provider  forecast.Provider
+	districts []types.District
+	window    func() (forecast.Query, forecast.Query)
 }
 
-func NewWeatherService(districts []types.District) *WeatherService {
+// RankingDays is how many forecast days the district averages cover
+const RankingDays = 7
+
+func NewWeatherService(provider forecast.Provider, districts []types.District) *WeatherService {
 	return &WeatherService{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 100,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		},
+		provider:  provider,
 		districts: districts,
 	}
 }
 
+// SetWindow makes the service fetch the forecast and air-quality date ranges
+// window returns instead of the provider's defaults, so other services
+// fetching the same ranges share cached series. The averages still cover only
+// the first RankingDays days.
+func (s *WeatherService) SetWindow(window func() (forecast.Query, forecast.Query)) {
+	s.window = window
+}
+
+// queries returns the forecast and air-quality date ranges to fetch
+func (s *WeatherService) queries() (forecast.Query, forecast.Query) {
+	if s.window == nil {
+		return forecast.Query{}, forecast.Query{}
+	}
+	return s.window()
+}
+
 // fetchResult holds the result of concurrent fetching
 type fetchResult struct {
-	District   types.District
-	AvgTemp2PM float64
-	AvgPM25    float64
-	Err        error
+	District types.District
+	Weather  types.DistrictWeather
+	Err      error
+}
+
+// RankOptions controls how districts are ordered
+type RankOptions struct {
+	RankBy     string   // RankByTemperature or RankByAirQuality
+	Pollutants []string // Pollutant keys combined into the air-quality score
+	DivisionID string   // Only rank this division's districts; empty ranks all
+}
+
+const (
+	// RankByTemperature orders by coolest first, breaking ties by air quality
+	RankByTemperature = "temperature"
+	// RankByAirQuality orders by cleanest first, breaking ties by temperature
+	RankByAirQuality = "air_quality"
+)
+
+// DefaultRankOptions ranks by temperature with PM2.5 as the tie-breaker
+var DefaultRankOptions = RankOptions{
+	RankBy:     RankByTemperature,
+	Pollutants: pollutant.Default,
 }
 
 // GetTopCoolestAndCleanest fetches weather data for all districts concurrently
 // and returns the top 10 coolest and cleanest districts
 func (s *WeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]types.DistrictWeather, error) {
+	districtWeathers, err := s.GetAllDistrictWeather(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := s.rankDistricts(districtWeathers)
+
+	return ranked, nil
+}
+
+// GetAllDistrictWeather fetches weather data for all districts concurrently
+// and returns them unranked
+func (s *WeatherService) GetAllDistrictWeather(ctx context.Context) ([]types.DistrictWeather, error) {
 	results := make(chan fetchResult, len(s.districts))
 	var wg sync.WaitGroup
 
@@ -57,12 +104,11 @@ func (s *WeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]types.
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
-			avgTemp, avgPM25, err := s.fetchDistrictData(ctx, d)
+			weather, err := s.fetchDistrictData(ctx, d)
 			results <- fetchResult{
-				District:   d,
-				AvgTemp2PM: avgTemp,
-				AvgPM25:    avgPM25,
-				Err:        err,
+				District: d,
+				Weather:  weather,
+				Err:      err,
 			}
 		}(district)
 	}
@@ -82,27 +128,23 @@ func (s *WeatherService) GetTopCoolestAndCleanest(ctx context.Context) ([]types.
 			continue
 		}
 
-		districtWeathers = append(districtWeathers, types.DistrictWeather{
-			ID:         result.District.ID,
-			Name:       result.District.Name,
-			AvgTemp2PM: result.AvgTemp2PM,
-			AvgPM25:    result.AvgPM25,
-		})
+		districtWeathers = append(districtWeathers, result.Weather)
 	}
 
-	ranked := s.rankDistricts(districtWeathers)
-
-	return ranked, nil
+	return districtWeathers, nil
 }
 
 // fetchDistrictData fetches both weather and air quality data for a district
-func (s *WeatherService) fetchDistrictData(ctx context.Context, d types.District) (float64, float64, error) {
+func (s *WeatherService) fetchDistrictData(ctx context.Context, d types.District) (types.DistrictWeather, error) {
 	var (
-		avgTemp float64
-		avgPM25 float64
-		tempErr error
-		aqErr   error
-		wg      sync.WaitGroup
+		avgTemp    float64
+		uv         []float64
+		debug      *types.ForecastDebug
+		airQuality types.Pollutants
+		aqiReport  types.AQIReport
+		tempErr    error
+		aqErr      error
+		wg         sync.WaitGroup
 	)
 
 	// Fetch weather and air quality concurrently
@@ -110,144 +152,228 @@ func (s *WeatherService) fetchDistrictData(ctx context.Context, d types.District
 
 	go func() {
 		defer wg.Done()
-		avgTemp, tempErr = s.fetchTemperature(ctx, d.Lat, d.Long)
+		avgTemp, uv, debug, tempErr = s.fetchForecast(ctx, d.Lat, d.Long)
 	}()
 
 	go func() {
 		defer wg.Done()
-		avgPM25, aqErr = s.fetchAirQuality(ctx, d.Lat, d.Long)
+		airQuality, aqiReport, aqErr = s.fetchAirQuality(ctx, d.Lat, d.Long)
 	}()
 
 	wg.Wait()
 
 	if tempErr != nil {
-		return 0, 0, tempErr
+		return types.DistrictWeather{}, tempErr
 	}
 	if aqErr != nil {
-		return 0, 0, aqErr
+		return types.DistrictWeather{}, aqErr
 	}
 
-	return avgTemp, avgPM25, nil
+	return types.DistrictWeather{
+		ID:            d.ID,
+		Name:          d.Name,
+		DivisionID:    d.DivisionID,
+		DivisionName:  d.DivisionName,
+		AvgTemp2PM:    avgTemp,
+		AvgPM25:       airQuality.PM25,
+		AvgPM10:       airQuality.PM10,
+		AvgNO2:        airQuality.NO2,
+		AvgO3:         airQuality.O3,
+		AvgSO2:        airQuality.SO2,
+		AvgCO:         airQuality.CO,
+		AQI:           aqiReport,
+		AvgUVIndex2PM: averageUV(uv),
+		UVCategory:    uvCategory(uv),
+		Debug:         debug,
+	}, nil
 }
 
-// fetchTemperature fetches 7-day hourly forecast and calculates avg temp at 2PM
-func (s *WeatherService) fetchTemperature(ctx context.Context, lat, long float64) (float64, error) {
-	url := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=temperature_2m&timezone=auto",
-		lat, long,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchForecast fetches 7-day hourly forecast and calculates avg temp at 2PM,
+// returning the 2PM UV index values found alongside. When the provider blends
+// several models, the per-model averages are returned for debugging.
+func (s *WeatherService) fetchForecast(ctx context.Context, lat, long float64) (float64, []float64, *types.ForecastDebug, error) {
+	query, _ := s.queries()
+	hourly, err := s.provider.Forecast(ctx, lat, long, query)
 	if err != nil {
-		return 0, err
+		return 0, nil, nil, err
 	}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return 0, err
+	// Calculate average temperature and UV index at 2PM (14:00) for all 7 days
+	indices := firstDays(hourly.Time, forecast.HourIndices(hourly.Time, 14), RankingDays)
+	temps := forecast.ValuesAt(hourly.Temperature, indices)
+	if len(temps) == 0 {
+		return 0, nil, nil, fmt.Errorf("no 2PM temperature data found")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("weather API returned status %d", resp.StatusCode)
+	var debug *types.ForecastDebug
+	if len(hourly.Models) > 0 {
+		debug = &types.ForecastDebug{
+			BlendMethod:     hourly.BlendMethod,
+			ModelTemp2PM:    make(map[string]float64, len(hourly.Models)),
+			ModelUVIndex2PM: make(map[string]float64, len(hourly.Models)),
+		}
+		for model, h := range hourly.Models {
+			modelIndices := firstDays(h.Time, forecast.HourIndices(h.Time, 14), RankingDays)
+			debug.ModelTemp2PM[model] = average(forecast.ValuesAt(h.Temperature, modelIndices))
+			debug.ModelUVIndex2PM[model] = average(forecast.ValuesAt(h.UVIndex, modelIndices))
+		}
 	}
 
-	var data types.OpenMeteoForecastResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
-	}
+	// UV index is supplementary, so missing values don't fail the fetch
+	return average(temps), forecast.ValuesAt(hourly.UVIndex, indices), debug, nil
+}
 
-	// Calculate average temperature at 2PM (14:00) for all 7 days
-	var temps []float64
-	for i, timeStr := range data.Hourly.Time {
-		// Time format: "2025-12-25T14:00"
-		if len(timeStr) >= 13 && timeStr[11:13] == "14" {
-			if i < len(data.Hourly.Temperature2m) {
-				temps = append(temps, data.Hourly.Temperature2m[i])
-			}
-		}
+// fetchAirQuality fetches air quality data and calculates avg pollutant levels
+// at 2PM. The AQI is computed from each day's concentrations averaged over
+// each pollutant's breakpoint period, then averaged across the days.
+func (s *WeatherService) fetchAirQuality(ctx context.Context, lat, long float64) (types.Pollutants, types.AQIReport, error) {
+	_, query := s.queries()
+	hourly, err := s.provider.AirQuality(ctx, lat, long, query)
+	if err != nil {
+		return types.Pollutants{}, types.AQIReport{}, err
 	}
 
-	if len(temps) == 0 {
-		return 0, fmt.Errorf("no 2PM temperature data found")
+	// PM2.5 is required; the other pollutants are best-effort
+	indices := firstDays(hourly.Time, forecast.HourIndices(hourly.Time, 14), RankingDays)
+	pm25 := forecast.ValuesAt(hourly.PM25, indices)
+	if len(pm25) == 0 {
+		return types.Pollutants{}, types.AQIReport{}, fmt.Errorf("no 2PM PM2.5 data found")
 	}
 
-	var sum float64
-	for _, t := range temps {
-		sum += t
+	var inputs struct{ pm25, pm10, no2, o3, so2, co []float64 }
+	for _, i := range indices {
+		p := hourly.AQIInputs(i)
+		inputs.pm25 = append(inputs.pm25, p.PM25)
+		inputs.pm10 = append(inputs.pm10, p.PM10)
+		inputs.no2 = append(inputs.no2, p.NO2)
+		inputs.o3 = append(inputs.o3, p.O3)
+		inputs.so2 = append(inputs.so2, p.SO2)
+		inputs.co = append(inputs.co, p.CO)
 	}
-	avg := sum / float64(len(temps))
+	report := aqi.Report(types.Pollutants{
+		PM25: average(inputs.pm25),
+		PM10: average(inputs.pm10),
+		NO2:  average(inputs.no2),
+		O3:   average(inputs.o3),
+		SO2:  average(inputs.so2),
+		CO:   average(inputs.co),
+	})
 
-	return math.Round(avg*100) / 100, nil
+	return types.Pollutants{
+		PM25: average(pm25),
+		PM10: average(forecast.ValuesAt(hourly.PM10, indices)),
+		NO2:  average(forecast.ValuesAt(hourly.NO2, indices)),
+		O3:   average(forecast.ValuesAt(hourly.O3, indices)),
+		SO2:  average(forecast.ValuesAt(hourly.SO2, indices)),
+		CO:   average(forecast.ValuesAt(hourly.CO, indices)),
+	}, report, nil
 }
 
-// fetchAirQuality fetches air quality data and calculates avg PM2.5
-func (s *WeatherService) fetchAirQuality(ctx context.Context, lat, long float64) (float64, error) {
-	url := fmt.Sprintf(
-		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&hourly=pm2_5&timezone=auto",
-		lat, long,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return 0, err
+// firstDays keeps the indices that fall on the first n dates of the series
+func firstDays(times []string, indices []int, n int) []int {
+	if len(times) == 0 {
+		return nil
 	}
-
-	resp, err := s.httpClient.Do(req)
+	first, err := time.Parse("2006-01-02", times[0][:10])
 	if err != nil {
-		return 0, err
+		return indices
 	}
-	defer resp.Body.Close()
+	end := first.AddDate(0, 0, n).Format("2006-01-02")
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("air quality API returned status %d", resp.StatusCode)
+	kept := make([]int, 0, len(indices))
+	for _, i := range indices {
+		if times[i][:10] < end {
+			kept = append(kept, i)
+		}
 	}
+	return kept
+}
 
-	var data types.OpenMeteoAirQualityResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+// averageUV is the average UV index, nil when UV is missing
+func averageUV(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
 	}
+	avg := average(values)
+	return &avg
+}
 
-	// Calculate average PM2.5 at 2PM for all days
-	var pm25Values []float64
-	for i, timeStr := range data.Hourly.Time {
-		if len(timeStr) >= 13 && timeStr[11:13] == "14" {
-			if i < len(data.Hourly.PM25) {
-				pm25Values = append(pm25Values, data.Hourly.PM25[i])
-			}
-		}
+// uvCategory labels the average UV index. Missing UV has no category, rather
+// than counting as low.
+func uvCategory(values []float64) string {
+	if len(values) == 0 {
+		return ""
 	}
+	return uvindex.Category(average(values))
+}
 
-	if len(pm25Values) == 0 {
-		return 0, fmt.Errorf("no 2PM PM2.5 data found")
+// average returns the mean of values rounded to 2 decimal places, or 0 if empty
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
 	}
 
 	var sum float64
-	for _, v := range pm25Values {
+	for _, v := range values {
 		sum += v
 	}
-	avg := sum / float64(len(pm25Values))
+	avg := sum / float64(len(values))
 
-	return math.Round(avg*100) / 100, nil
+	return math.Round(avg*100) / 100
 }
 
 // rankDistricts ranks districts by coolest temperature first,
 // breaking ties by better air quality (lower PM2.5)
 // returns top 10 coolest and cleanest districts
 func (s *WeatherService) rankDistricts(districts []types.DistrictWeather) []types.DistrictWeather {
+	return s.rankDistrictsBy(districts, DefaultRankOptions)
+}
+
+// rankDistrictsBy ranks districts using the given options and returns the top 10.
+// Each district's PollutantScore is set from the selected pollutants.
+func (s *WeatherService) rankDistrictsBy(districts []types.DistrictWeather, opts RankOptions) []types.DistrictWeather {
+	if opts.DivisionID != "" {
+		var inDivision []types.DistrictWeather
+		for _, d := range districts {
+			if d.DivisionID == opts.DivisionID {
+				inDivision = append(inDivision, d)
+			}
+		}
+		districts = inDivision
+	}
+
 	if len(districts) == 0 {
 		return districts
 	}
 
-	// Sort by temperature (ascending), then by PM2.5 (ascending) for ties
+	for i := range districts {
+		districts[i].PollutantScore = math.Round(pollutant.Score(districts[i].AirQuality(), opts.Pollutants)*100) / 100
+	}
+
+	// Compare unrounded scores so close districts still order deterministically
+	score := func(d types.DistrictWeather) float64 {
+		return pollutant.Score(d.AirQuality(), opts.Pollutants)
+	}
+
 	sort.Slice(districts, func(i, j int) bool {
-		if districts[i].AvgTemp2PM != districts[j].AvgTemp2PM {
-			return districts[i].AvgTemp2PM < districts[j].AvgTemp2PM
+		ti, tj := districts[i].AvgTemp2PM, districts[j].AvgTemp2PM
+		si, sj := score(districts[i]), score(districts[j])
+
+		if opts.RankBy == RankByAirQuality {
+			if si != sj {
+				return si < sj
+			}
+			return ti < tj
 		}
-		return districts[i].AvgPM25 < districts[j].AvgPM25
+
+		// Sort by temperature (ascending), then by air quality (ascending) for ties
+		if ti != tj {
+			return ti < tj
+		}
+		return si < sj
 	})
 
-	topTenDistricts := districts[:10]
+	topTenDistricts := districts[:min(10, len(districts))]
 
 	for i := range topTenDistricts {
 		topTenDistricts[i].Rank = i + 1
@@ -255,3 +381,45 @@ func (s *WeatherService) rankDistricts(districts []types.DistrictWeather) []type
 
 	return topTenDistricts
 }
+
+// summarizeDivisions averages each division's districts and picks its best
+// district with the given options. Divisions without data are left out.
+func (s *WeatherService) summarizeDivisions(districts []types.DistrictWeather, divisions []types.Division, opts RankOptions) []types.DivisionSummary {
+	byDivision := make(map[string][]types.DistrictWeather)
+	for _, d := range districts {
+		byDivision[d.DivisionID] = append(byDivision[d.DivisionID], d)
+	}
+
+	var summaries []types.DivisionSummary
+	for _, division := range divisions {
+		members := byDivision[division.ID]
+		if len(members) == 0 {
+			continue
+		}
+
+		temps := make([]float64, len(members))
+		pm25 := make([]float64, len(members))
+		for i, d := range members {
+			temps[i] = d.AvgTemp2PM
+			pm25[i] = d.AvgPM25
+		}
+
+		opts.DivisionID = ""
+		best := s.rankDistrictsBy(members, opts)[0]
+		best.Debug = nil
+		// Its rank within the division would read as a national rank
+		best.Rank = 0
+
+		summaries = append(summaries, types.DivisionSummary{
+			ID:           division.ID,
+			Name:         division.Name,
+			BnName:       division.BnName,
+			Districts:    len(members),
+			AvgTemp2PM:   average(temps),
+			AvgPM25:      average(pm25),
+			BestDistrict: best,
+		})
+	}
+
+	return summaries
+}
@@ -2,9 +2,11 @@ package weather
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/shuv1824/recommender/internal/services/forecast"
 	"github.com/shuv1824/recommender/internal/types"
 )
 
@@ -76,7 +78,7 @@ func TestRankDistricts(t *testing.T) {
 				return
 			}
 
-			// rankDistricts always returns exactly 10 (or panics if less than 10 input)
+			// rankDistricts returns at most 10 districts
 			if len(result) != 10 {
 				t.Fatalf("expected 10 districts, got %d", len(result))
 			}
@@ -123,6 +125,113 @@ func TestRankDistrictsReturnsTop10(t *testing.T) {
 	}
 }
 
+// TestRankDistrictsBy tests ranking on selected pollutants
+func TestRankDistrictsBy(t *testing.T) {
+	s := &WeatherService{}
+
+	tests := []struct {
+		name        string
+		opts        RankOptions
+		input       []types.DistrictWeather
+		expectedIDs []string
+	}{
+		{
+			name: "breaks temperature ties by combined pollutant score",
+			opts: RankOptions{RankBy: RankByTemperature, Pollutants: []string{"pm25", "o3"}},
+			input: []types.DistrictWeather{
+				{ID: "1", AvgTemp2PM: 25.0, AvgPM25: 15.0, AvgO3: 200.0}, // score 1.5
+				{ID: "2", AvgTemp2PM: 25.0, AvgPM25: 30.0, AvgO3: 50.0},  // score 1.25
+				{ID: "3", AvgTemp2PM: 24.0, AvgPM25: 90.0, AvgO3: 300.0},
+			},
+			expectedIDs: []string{"3", "2", "1"},
+		},
+		{
+			name: "ranks by air quality first when requested",
+			opts: RankOptions{RankBy: RankByAirQuality, Pollutants: []string{"no2"}},
+			input: []types.DistrictWeather{
+				{ID: "1", AvgTemp2PM: 22.0, AvgNO2: 40.0},
+				{ID: "2", AvgTemp2PM: 30.0, AvgNO2: 10.0},
+				{ID: "3", AvgTemp2PM: 26.0, AvgNO2: 10.0},
+			},
+			expectedIDs: []string{"3", "2", "1"},
+		},
+		{
+			name: "only ranks the chosen division",
+			opts: RankOptions{RankBy: RankByTemperature, Pollutants: []string{"pm25"}, DivisionID: "7"},
+			input: []types.DistrictWeather{
+				{ID: "1", DivisionID: "3", AvgTemp2PM: 22.0},
+				{ID: "54", DivisionID: "7", AvgTemp2PM: 27.0},
+				{ID: "51", DivisionID: "7", AvgTemp2PM: 26.0},
+			},
+			expectedIDs: []string{"51", "54"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.rankDistrictsBy(tt.input, tt.opts)
+
+			if len(result) != len(tt.expectedIDs) {
+				t.Fatalf("expected %d districts, got %d", len(tt.expectedIDs), len(result))
+			}
+
+			for i, id := range tt.expectedIDs {
+				if result[i].ID != id {
+					t.Errorf("at position %d: expected ID %s, got %s", i, id, result[i].ID)
+				}
+				if result[i].Rank != i+1 {
+					t.Errorf("at position %d: expected rank %d, got %d", i, i+1, result[i].Rank)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeDivisions(t *testing.T) {
+	s := &WeatherService{}
+
+	divisions := []types.Division{
+		{ID: "3", Name: "Dhaka", BnName: "ঢাকা"},
+		{ID: "6", Name: "Rangpur", BnName: "রংপুর"},
+		{ID: "7", Name: "Sylhet", BnName: "সিলেট"},
+	}
+	districts := []types.DistrictWeather{
+		{ID: "1", DivisionID: "3", AvgTemp2PM: 31.0, AvgPM25: 90.0},
+		{ID: "2", DivisionID: "3", AvgTemp2PM: 30.0, AvgPM25: 60.0},
+		{ID: "51", DivisionID: "7", AvgTemp2PM: 27.0, AvgPM25: 20.0},
+		{ID: "54", DivisionID: "7", AvgTemp2PM: 26.0, AvgPM25: 40.0},
+	}
+
+	t.Run("averages each division and picks the coolest district", func(t *testing.T) {
+		summaries := s.summarizeDivisions(districts, divisions, DefaultRankOptions)
+
+		// Rangpur has no data and is left out
+		if len(summaries) != 2 {
+			t.Fatalf("expected 2 divisions, got %d", len(summaries))
+		}
+
+		dhaka := summaries[0]
+		if dhaka.Name != "Dhaka" || dhaka.Districts != 2 || dhaka.AvgTemp2PM != 30.5 || dhaka.AvgPM25 != 75.0 {
+			t.Errorf("unexpected Dhaka summary: %+v", dhaka)
+		}
+		if dhaka.BestDistrict.ID != "2" || dhaka.BestDistrict.Rank != 0 {
+			t.Errorf("expected unranked district 2 to be best in Dhaka, got %s (rank %d)", dhaka.BestDistrict.ID, dhaka.BestDistrict.Rank)
+		}
+		if summaries[1].BestDistrict.ID != "54" {
+			t.Errorf("expected district 54 to be best in Sylhet, got %s", summaries[1].BestDistrict.ID)
+		}
+	})
+
+	t.Run("picks the cleanest district when ranking by air quality", func(t *testing.T) {
+		opts := RankOptions{RankBy: RankByAirQuality, Pollutants: []string{"pm25"}}
+		summaries := s.summarizeDivisions(districts, divisions, opts)
+
+		if summaries[1].BestDistrict.ID != "51" {
+			t.Errorf("expected district 51 to be best in Sylhet, got %s", summaries[1].BestDistrict.ID)
+		}
+	})
+}
+
 // TestCachedWeatherService tests the caching logic
 func TestCachedWeatherService(t *testing.T) {
 	t.Run("returns cached data within TTL", func(t *testing.T) {
@@ -130,7 +239,7 @@ func TestCachedWeatherService(t *testing.T) {
 			{ID: "1", Name: "Test", Lat: 23.0, Long: 90.0},
 		}
 
-		svc := NewCachedWeatherService(districts, 1*time.Hour)
+		svc := NewCachedWeatherService(forecast.NewOpenMeteo(nil), districts, 1*time.Hour)
 
 		// Manually set cache
 		svc.mu.Lock()
@@ -161,7 +270,7 @@ func TestCachedWeatherService(t *testing.T) {
 			{ID: "1", Name: "Test", Lat: 23.0, Long: 90.0},
 		}
 
-		svc := NewCachedWeatherService(districts, 10*time.Millisecond)
+		svc := NewCachedWeatherService(forecast.NewOpenMeteo(nil), districts, 10*time.Millisecond)
 
 		// Set cache with old timestamp
 		svc.mu.Lock()
@@ -197,7 +306,7 @@ func TestCachedWeatherService(t *testing.T) {
 			{ID: "1", Name: "Test", Lat: 23.0, Long: 90.0},
 		}
 
-		svc := NewCachedWeatherService(districts, 1*time.Hour)
+		svc := NewCachedWeatherService(forecast.NewOpenMeteo(nil), districts, 1*time.Hour)
 
 		svc.mu.Lock()
 		svc.cache = []types.DistrictWeather{
@@ -218,4 +327,107 @@ func TestCachedWeatherService(t *testing.T) {
 			t.Error("cache returned reference instead of copy")
 		}
 	})
+
+	t.Run("looks up one district without fetching", func(t *testing.T) {
+		districts := []types.District{
+			{ID: "1", Name: "Test", Lat: 23.0, Long: 90.0},
+		}
+
+		svc := NewCachedWeatherService(forecast.NewOpenMeteo(nil), districts, 1*time.Millisecond)
+
+		if _, _, ok := svc.CachedDistrict("1"); ok {
+			t.Fatal("expected no data before the cache is filled")
+		}
+
+		// Stale data is still the latest there is
+		updated := time.Now().Add(-1 * time.Hour)
+		svc.mu.Lock()
+		svc.cache = []types.DistrictWeather{
+			{ID: "1", Name: "Test", AvgTemp2PM: 25.0, AvgPM25: 30.0, Rank: 1},
+		}
+		svc.lastUpdated = updated
+		svc.mu.Unlock()
+
+		weather, updatedAt, ok := svc.CachedDistrict("1")
+		if !ok || weather.AvgTemp2PM != 25.0 || !updatedAt.Equal(updated) {
+			t.Errorf("expected the cached district, got %+v at %v (ok=%v)", weather, updatedAt, ok)
+		}
+		if weather.Rank != 0 {
+			t.Errorf("expected no rank, got %d", weather.Rank)
+		}
+		if _, _, ok := svc.CachedDistrict("2"); ok {
+			t.Error("expected an unknown district not to be found")
+		}
+	})
+}
+
+// windowProvider serves one 2PM value per day, the day's number, and records
+// the queries it was asked for
+type windowProvider struct {
+	days    int
+	mu      sync.Mutex
+	queries []forecast.Query
+}
+
+func (p *windowProvider) record(q forecast.Query) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queries = append(p.queries, q)
+}
+
+func (p *windowProvider) hourly() ([]string, []float64) {
+	start := time.Date(2026, 5, 1, 14, 0, 0, 0, time.UTC)
+	times := make([]string, p.days)
+	values := make([]float64, p.days)
+	for i := range times {
+		times[i] = start.AddDate(0, 0, i).Format("2006-01-02T15:04")
+		values[i] = float64(i + 1)
+	}
+	return times, values
+}
+
+func (p *windowProvider) Forecast(_ context.Context, _, _ float64, q forecast.Query) (*forecast.Hourly, error) {
+	p.record(q)
+	times, values := p.hourly()
+	return &forecast.Hourly{Time: times, Temperature: values}, nil
+}
+
+func (p *windowProvider) AirQuality(_ context.Context, _, _ float64, q forecast.Query) (*forecast.HourlyAirQuality, error) {
+	p.record(q)
+	times, values := p.hourly()
+	return &forecast.HourlyAirQuality{Time: times, PM25: values}, nil
+}
+
+func (p *windowProvider) TemperatureEnsemble(context.Context, float64, float64, forecast.Query) (*forecast.Ensemble, error) {
+	return nil, nil
+}
+
+func (p *windowProvider) Historical(context.Context, float64, float64, forecast.Query) (*forecast.Hourly, error) {
+	return nil, nil
+}
+
+func TestWeatherServiceWindow(t *testing.T) {
+	provider := &windowProvider{days: 16}
+	svc := NewWeatherService(provider, nil)
+	forecastQuery := forecast.Query{StartDate: "2026-05-01", EndDate: "2026-05-16"}
+	airQualityQuery := forecast.Query{StartDate: "2026-05-01", EndDate: "2026-05-05"}
+	svc.SetWindow(func() (forecast.Query, forecast.Query) { return forecastQuery, airQualityQuery })
+
+	weather, err := svc.fetchDistrictData(context.Background(), types.District{ID: "1", Name: "Test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	asked := map[forecast.Query]bool{}
+	for _, q := range provider.queries {
+		asked[q] = true
+	}
+	if !asked[forecastQuery] || !asked[airQualityQuery] {
+		t.Errorf("expected the window's queries, got %v", provider.queries)
+	}
+
+	// Days 1 to 7 of the 16-day window average to 4
+	if weather.AvgTemp2PM != 4 || weather.AvgPM25 != 4 {
+		t.Errorf("expected the first %d days to average 4, got temp %.2f and PM2.5 %.2f", RankingDays, weather.AvgTemp2PM, weather.AvgPM25)
+	}
 }
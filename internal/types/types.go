@@ -10,24 +10,104 @@ type RawDistrict struct {
 }
 
 type District struct {
-	ID         string  `json:"id"`
-	DivisionID string  `json:"division_id"`
-	Name       string  `json:"name"`
-	BnName     string  `json:"bn_name"`
-	Lat        float64 `json:"lat"`
-	Long       float64 `json:"long"`
+	ID           string  `json:"id"`
+	DivisionID   string  `json:"division_id"`
+	DivisionName string  `json:"division_name,omitempty"`
+	Name         string  `json:"name"`
+	BnName       string  `json:"bn_name"`
+	Lat          float64 `json:"lat"`
+	Long         float64 `json:"long"`
+}
+
+type RawUpazila struct {
+	ID         string `json:"id"`
+	DistrictID string `json:"district_id"`
+	Name       string `json:"name"`
+	BnName     string `json:"bn_name"`
+	Lat        string `json:"lat"`
+	Long       string `json:"long"`
+}
+
+// Upazila is a sub-district, linked to its parent district
+type Upazila struct {
+	ID           string  `json:"id"`
+	DistrictID   string  `json:"district_id"`
+	DistrictName string  `json:"district_name,omitempty"`
+	Name         string  `json:"name"`
+	BnName       string  `json:"bn_name"`
+	Lat          float64 `json:"lat"`
+	Long         float64 `json:"long"`
+}
+
+// Division is one of the eight administrative divisions districts belong to
+type Division struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	BnName string `json:"bn_name"`
 }
 
 type GeoData struct {
+	Divisions []Division    `json:"divisions"`
 	Districts []RawDistrict `json:"districts"`
+	Upazilas  []RawUpazila  `json:"upazilas"` // Kept in their own file
+}
+
+// Pollutants holds air-quality concentrations in µg/m³
+type Pollutants struct {
+	PM25 float64
+	PM10 float64
+	NO2  float64
+	O3   float64
+	SO2  float64
+	CO   float64
+}
+
+// AQI is an air quality index computed under one standard
+type AQI struct {
+	Value             int            `json:"value"`
+	Category          string         `json:"category"`
+	Color             string         `json:"color"`
+	DominantPollutant string         `json:"dominant_pollutant"`
+	SubIndices        map[string]int `json:"sub_indices"`
+}
+
+// AQIReport holds the AQI under each supported standard
+type AQIReport struct {
+	USEPA         AQI `json:"us_epa"`
+	BangladeshDoE AQI `json:"bd_doe"`
+}
+
+// ForecastDebug exposes per-model values behind a blended forecast
+type ForecastDebug struct {
+	BlendMethod     string             `json:"blend_method"`
+	ModelTemp2PM    map[string]float64 `json:"model_temp_2pm_celsius"`
+	ModelUVIndex2PM map[string]float64 `json:"model_uv_index_2pm"`
 }
 
 type DistrictWeather struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	AvgTemp2PM float64 `json:"avg_temp_2pm_celsius"`
-	AvgPM25    float64 `json:"avg_pm25"`
-	Rank       int     `json:"rank"`
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	DivisionID     string    `json:"division_id,omitempty"`
+	DivisionName   string    `json:"division_name,omitempty"`
+	AvgTemp2PM     float64   `json:"avg_temp_2pm_celsius"`
+	AvgPM25        float64   `json:"avg_pm25"`
+	AvgPM10        float64   `json:"avg_pm10"`
+	AvgNO2         float64   `json:"avg_no2"`
+	AvgO3          float64   `json:"avg_o3"`
+	AvgSO2         float64   `json:"avg_so2"`
+	AvgCO          float64   `json:"avg_co"`
+	PollutantScore float64   `json:"pollutant_score"`
+	AQI            AQIReport `json:"aqi"`
+	AvgUVIndex2PM  *float64  `json:"avg_uv_index_2pm,omitempty"` // Omitted when UV is unavailable
+	UVCategory     string    `json:"uv_category,omitempty"`      // Empty when UV is unavailable
+	Rank           int       `json:"rank"`
+	// Debug is only included when requested and the forecast was blended
+	Debug *ForecastDebug `json:"debug,omitempty"`
+}
+
+// AirQuality returns the district's average pollutant levels
+func (d DistrictWeather) AirQuality() Pollutants {
+	return Pollutants{PM25: d.AvgPM25, PM10: d.AvgPM10, NO2: d.AvgNO2, O3: d.AvgO3, SO2: d.AvgSO2, CO: d.AvgCO}
 }
 
 type Location struct {
@@ -39,19 +119,58 @@ type Location struct {
 type TopDestinationsResponse struct {
 	GeneratedAt  string            `json:"generated_at"`
 	Description  string            `json:"description"`
+	Division     string            `json:"division,omitempty"` // Set when filtered to one division
+	RankBy       string            `json:"rank_by"`
+	Pollutants   []string          `json:"pollutants"`
 	Destinations []DistrictWeather `json:"destinations"`
 }
 
 type LocationWeather struct {
-	Name    string  `json:"name"`
-	Temp2PM float64 `json:"temp_2pm_celsius"`
-	PM25    float64 `json:"pm25"`
+	Name string `json:"name"`
+	// District names the district an upazila destination is in
+	District   string    `json:"district,omitempty"`
+	Temp2PM    float64   `json:"temp_2pm_celsius"`
+	PM25       float64   `json:"pm25"`
+	PM10       float64   `json:"pm10"`
+	NO2        float64   `json:"no2"`
+	O3         float64   `json:"o3"`
+	SO2        float64   `json:"so2"`
+	CO         float64   `json:"co"`
+	AQI        AQIReport `json:"aqi"`
+	UVIndex    *float64  `json:"uv_index_2pm,omitempty"` // Omitted when UV is unavailable
+	UVCategory string    `json:"uv_category,omitempty"`  // Empty when UV is unavailable
+	// TempEnsemble is only set when ensemble forecasts were requested
+	TempEnsemble *EnsembleStats `json:"temp_ensemble,omitempty"`
+	// Debug is only included when requested and the forecast was blended
+	Debug *ForecastDebug `json:"debug,omitempty"`
+}
+
+// AirQuality returns the location's pollutant levels
+func (l LocationWeather) AirQuality() Pollutants {
+	return Pollutants{PM25: l.PM25, PM10: l.PM10, NO2: l.NO2, O3: l.O3, SO2: l.SO2, CO: l.CO}
 }
 
 type TravelRequest struct {
-	CurrentLocation         Location `json:"current_location"`
-	DestinationDistrictName string   `json:"destination_district"`
-	TravelDate              string   `json:"travel_date"` // Format: YYYY-MM-DD
+	CurrentLocation         Location  `json:"current_location"`
+	DestinationDistrictName string    `json:"destination_district"`              // English or Bangla name
+	DestinationDistrictID   string    `json:"destination_district_id,omitempty"` // Instead of or alongside the name
+	DestinationUpazila      string    `json:"destination_upazila,omitempty"`     // Upazila ID or name, within the district if one is set
+	DestinationLocation     *Location `json:"destination_location,omitempty"`    // Coordinates instead of a district
+	TravelDate              string    `json:"travel_date"`                       // Format: YYYY-MM-DD
+	StartDate               string    `json:"start_date,omitempty"`              // Multi-day trips, instead of TravelDate
+	EndDate                 string    `json:"end_date,omitempty"`                // Inclusive
+	Pollutants              []string  `json:"pollutants,omitempty"`              // Defaults to PM2.5 only
+	TravelerProfile         string    `json:"traveler_profile,omitempty"`        // Defaults to general
+	Ensemble                bool      `json:"ensemble,omitempty"`                // Fetch ensemble forecasts for uncertainty
+	Debug                   bool      `json:"debug,omitempty"`                   // Include per-model forecast values
+	// Route samples conditions along the way when set
+	Route *RouteRequest `json:"route,omitempty"`
+}
+
+// RouteRequest asks for conditions along the route to the destination
+type RouteRequest struct {
+	Samples  int        `json:"samples,omitempty"`  // Points including both ends; defaults to 6
+	Polyline []Location `json:"polyline,omitempty"` // Waypoints between origin and destination; great circle when empty
 }
 
 // TravelRequestBody is the request body for travel recommendation
@@ -61,33 +180,410 @@ type TravelRequestBody struct {
 		Long float64 `json:"long"`
 		Name string  `json:"name,omitempty"`
 	} `json:"current_location"`
-	DestinationDistrictName string `json:"destination_district"`
-	TravelDate              string `json:"travel_date"`
+	DestinationDistrictName string        `json:"destination_district"`
+	DestinationDistrictID   string        `json:"destination_district_id,omitempty"`
+	DestinationUpazila      string        `json:"destination_upazila,omitempty"`
+	DestinationLocation     *Location     `json:"destination_location,omitempty"`
+	TravelDate              string        `json:"travel_date"`
+	StartDate               string        `json:"start_date,omitempty"`
+	EndDate                 string        `json:"end_date,omitempty"`
+	Pollutants              []string      `json:"pollutants,omitempty"`
+	TravelerProfile         string        `json:"traveler_profile,omitempty"`
+	Ensemble                bool          `json:"ensemble,omitempty"`
+	Debug                   bool          `json:"debug,omitempty"`
+	Route                   *RouteRequest `json:"route,omitempty"`
+}
+
+// GuidelineExceedance reports a pollutant above its WHO guideline value
+type GuidelineExceedance struct {
+	Pollutant     string  `json:"pollutant"`
+	Concentration float64 `json:"concentration"`
+	Guideline     float64 `json:"guideline"`
+	Ratio         float64 `json:"ratio"`
+}
+
+// HealthAdvisory is WHO guideline-based advice for a location
+type HealthAdvisory struct {
+	Level              string                `json:"level"`
+	GeneralPublic      string                `json:"general_public"`
+	SensitiveGroups    string                `json:"sensitive_groups"`
+	MaskRecommendation string                `json:"mask_recommendation"`
+	Summary            string                `json:"summary"`
+	Exceedances        []GuidelineExceedance `json:"exceedances"`
+}
+
+// ProfileRule is a traveler-profile rule and whether the destination passed it
+type ProfileRule struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+}
+
+// TravelerProfileReport echoes the traveler profile used for a recommendation
+type TravelerProfileReport struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	AppliedRules []ProfileRule `json:"applied_rules"`
+}
+
+// FactorContribution is one factor's share of a recommendation score.
+// Points are relative to the neutral score of 50.
+type FactorContribution struct {
+	Factor     string  `json:"factor"`
+	Difference float64 `json:"difference"`
+	Weight     float64 `json:"weight"`
+	Points     float64 `json:"points"`
+}
+
+// EnsembleStats summarizes the spread of ensemble forecast members
+type EnsembleStats struct {
+	Members int     `json:"members"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"std_dev"`
+	Min     float64 `json:"min"`
+	P10     float64 `json:"p10"`
+	P50     float64 `json:"p50"`
+	P90     float64 `json:"p90"`
+	Max     float64 `json:"max"`
+	Spread  float64 `json:"spread"` // P90 - P10
+}
+
+// ForecastConfidence rates how reliable the forecast behind a recommendation is
+type ForecastConfidence struct {
+	Level   string   `json:"level"`
+	Score   float64  `json:"score"`           // 0-1
+	Range   string   `json:"range,omitempty"` // short, medium or extended; empty for climatology
+	Reasons []string `json:"reasons"`
 }
 
 // TravelRecommendation is the API response
 type TravelRecommendation struct {
-	Recommendation     string          `json:"recommendation"`
-	Reason             string          `json:"reason"`
-	TravelDate         string          `json:"travel_date"`
-	CurrentWeather     LocationWeather `json:"current_location"`
-	DestinationWeather LocationWeather `json:"destination"`
-	TempDifference     float64         `json:"temp_difference_celsius"`
-	PM25Difference     float64         `json:"pm25_difference"`
-}
-
-// OpenMeteoForecastResponse represents the weather API response
-type OpenMeteoForecastResponse struct {
-	Hourly struct {
-		Time          []string  `json:"time"`
-		Temperature2m []float64 `json:"temperature_2m"`
-	} `json:"hourly"`
-}
-
-// OpenMeteoAirQualityResponse represents the air quality API response
-type OpenMeteoAirQualityResponse struct {
-	Hourly struct {
-		Time []string  `json:"time"`
-		PM25 []float64 `json:"pm2_5"`
-	} `json:"hourly"`
+	Recommendation       string                `json:"recommendation"`
+	Score                float64               `json:"score"`
+	Basis                string                `json:"basis"`            // forecast, climatology or historical
+	TemperatureOnly      bool                  `json:"temperature_only"` // Beyond the air-quality horizon
+	Confidence           ForecastConfidence    `json:"confidence"`
+	Factors              []FactorContribution  `json:"factors"`
+	Reason               string                `json:"reason"`
+	TravelDate           string                `json:"travel_date"`
+	CurrentWeather       LocationWeather       `json:"current_location"`
+	Origin               *ReverseGeocode       `json:"origin,omitempty"` // Where the current location is
+	DestinationWeather   LocationWeather       `json:"destination"`
+	TempDifference       float64               `json:"temp_difference_celsius"`
+	PM25Difference       float64               `json:"pm25_difference"`
+	Pollutants           []string              `json:"compared_pollutants"`
+	PollutantDifferences map[string]float64    `json:"pollutant_differences"`
+	AQIDifference        int                   `json:"us_aqi_difference"`
+	UVIndexDifference    *float64              `json:"uv_index_difference,omitempty"` // Omitted unless both locations have UV
+	HealthAdvisory       HealthAdvisory        `json:"health_advisory"`
+	TravelerProfile      TravelerProfileReport `json:"traveler_profile"`
+	// Route is only included when route sampling was requested
+	Route *RouteReport `json:"route,omitempty"`
+}
+
+// RoutePoint is the weather at one sampled point along the route
+type RoutePoint struct {
+	Lat        float64  `json:"lat"`
+	Long       float64  `json:"long"`
+	DistanceKm float64  `json:"distance_km"` // From the origin
+	Temp2PM    float64  `json:"temp_2pm_celsius"`
+	PM25       float64  `json:"pm25"`
+	UVIndex    *float64 `json:"uv_index_2pm,omitempty"` // Omitted when UV is unavailable
+	Score      float64  `json:"score"`                  // Compared with the origin, like a destination
+}
+
+// RouteSegment is the stretch between two neighbouring route points
+type RouteSegment struct {
+	FromKm         float64 `json:"from_km"`
+	ToKm           float64 `json:"to_km"`
+	Recommendation string  `json:"recommendation"`
+	Score          float64 `json:"score"` // The lower of its two points
+	MaxTemp2PM     float64 `json:"max_temp_2pm_celsius"`
+	MaxPM25        float64 `json:"max_pm25"`
+	ExtremeHeat    bool    `json:"extreme_heat"`
+}
+
+// RouteReport summarizes conditions along the route on the travel date
+type RouteReport struct {
+	DistanceKm float64 `json:"distance_km"`
+	// Unavailable is set when the route's weather couldn't be fetched; Error
+	// says why, and there are no points or worst segment
+	Unavailable  bool          `json:"unavailable,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Points       []RoutePoint  `json:"points,omitempty"`
+	WorstSegment *RouteSegment `json:"worst_segment,omitempty"`
+}
+
+// TripSummary aggregates a multi-day trip's per-day comparisons
+type TripSummary struct {
+	Days                int      `json:"days"`
+	AvgScore            float64  `json:"avg_score"`
+	WorstDay            string   `json:"worst_day"`
+	WorstScore          float64  `json:"worst_score"`
+	BestDay             string   `json:"best_day"`
+	BestScore           float64  `json:"best_score"`
+	AvgTempDifference   float64  `json:"avg_temp_difference_celsius"`
+	AvgDestTemp2PM      float64  `json:"avg_destination_temp_2pm_celsius"`
+	MaxDestTemp2PM      float64  `json:"max_destination_temp_2pm_celsius"`
+	AvgDestPM25         float64  `json:"avg_destination_pm25"`
+	MaxDestPM25         float64  `json:"max_destination_pm25"`
+	ExtremeHeatDays     []string `json:"extreme_heat_days"`
+	TemperatureOnlyDays int      `json:"temperature_only_days"`
+	// TemperatureOnlyAvgScore averages the temperature-only days when the
+	// trip also has fully compared days, which the other scores cover
+	TemperatureOnlyAvgScore *float64 `json:"temperature_only_avg_score,omitempty"`
+}
+
+// TripRecommendation grades a multi-day trip
+type TripRecommendation struct {
+	Recommendation string                 `json:"recommendation"`
+	Score          float64                `json:"score"`
+	Reason         string                 `json:"reason"`
+	StartDate      string                 `json:"start_date"`
+	EndDate        string                 `json:"end_date"`
+	Summary        TripSummary            `json:"summary"`
+	Days           []TravelRecommendation `json:"days"`
+}
+
+// BestDayRequest asks which forecast day is best for a trip to a destination
+type BestDayRequest struct {
+	CurrentLocation         Location  `json:"current_location"`
+	DestinationDistrictName string    `json:"destination_district"`
+	DestinationDistrictID   string    `json:"destination_district_id,omitempty"`
+	DestinationUpazila      string    `json:"destination_upazila,omitempty"`
+	DestinationLocation     *Location `json:"destination_location,omitempty"`
+	Pollutants              []string  `json:"pollutants,omitempty"`
+	TravelerProfile         string    `json:"traveler_profile,omitempty"`
+}
+
+// BestDayResponse scores every forecast day and names the best one
+type BestDayResponse struct {
+	Destination    string                 `json:"destination"`
+	Origin         *ReverseGeocode        `json:"origin,omitempty"` // Where the current location is
+	BestDay        string                 `json:"best_day"`
+	Recommendation string                 `json:"recommendation"`
+	Reason         string                 `json:"reason"`
+	Days           []TravelRecommendation `json:"days"`
+}
+
+// BestDestinationsRequest asks which districts are best to visit on a date
+type BestDestinationsRequest struct {
+	CurrentLocation Location `json:"current_location"`
+	TravelDate      string   `json:"travel_date"` // Format: YYYY-MM-DD
+	Pollutants      []string `json:"pollutants,omitempty"`
+	TravelerProfile string   `json:"traveler_profile,omitempty"`
+	District        string   `json:"district,omitempty"` // Rank this district's upazilas instead of every district
+}
+
+// DestinationComparison is one district compared with the current location
+type DestinationComparison struct {
+	Rank                 int                `json:"rank"`
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	Division             string             `json:"division,omitempty"`
+	District             string             `json:"district,omitempty"` // Set for upazilas
+	Recommendation       string             `json:"recommendation"`
+	Score                float64            `json:"score"`
+	Reason               string             `json:"reason"`
+	Basis                string             `json:"basis"`
+	TemperatureOnly      bool               `json:"temperature_only"`
+	TempDifference       float64            `json:"temp_difference_celsius"`
+	PollutantDifferences map[string]float64 `json:"pollutant_differences"`
+	AQIDifference        int                `json:"us_aqi_difference"`
+	UVIndexDifference    *float64           `json:"uv_index_difference,omitempty"` // Omitted unless both locations have UV
+	Destination          LocationWeather    `json:"destination"`
+}
+
+// DestinationRanking ranks districts against the current location
+type DestinationRanking struct {
+	TravelDate      string                  `json:"travel_date"`
+	District        string                  `json:"district,omitempty"` // Set when ranking one district's upazilas
+	CurrentWeather  LocationWeather         `json:"current_location"`
+	Origin          *ReverseGeocode         `json:"origin,omitempty"` // Where the current location is
+	Pollutants      []string                `json:"compared_pollutants"`
+	TravelerProfile string                  `json:"traveler_profile"`
+	Destinations    []DestinationComparison `json:"destinations"`
+}
+
+// CompareRequest asks how a shortlist of districts compare on a date
+type CompareRequest struct {
+	CurrentLocation          Location `json:"current_location"`
+	DestinationDistrictNames []string `json:"destination_districts"`
+	TravelDate               string   `json:"travel_date"` // Format: YYYY-MM-DD
+	Pollutants               []string `json:"pollutants,omitempty"`
+	TravelerProfile          string   `json:"traveler_profile,omitempty"`
+}
+
+// ItineraryStop is one district visited on one date
+type ItineraryStop struct {
+	DistrictName string `json:"district"`
+	Date         string `json:"date"` // Format: YYYY-MM-DD
+}
+
+// ItineraryRequest asks how an ordered multi-stop trip compares stop by stop
+type ItineraryRequest struct {
+	CurrentLocation Location        `json:"current_location"`
+	Stops           []ItineraryStop `json:"stops"`
+	Pollutants      []string        `json:"pollutants,omitempty"`
+	TravelerProfile string          `json:"traveler_profile,omitempty"`
+}
+
+// ItineraryStopReport compares a stop with the current location on the stop's date
+type ItineraryStopReport struct {
+	DistrictName    string          `json:"district"`
+	Date            string          `json:"date"`
+	Basis           string          `json:"basis"`
+	TemperatureOnly bool            `json:"temperature_only"`
+	Recommendation  string          `json:"recommendation"`
+	Score           float64         `json:"score"`
+	Reason          string          `json:"reason"`
+	Weather         LocationWeather `json:"weather"`
+}
+
+// ItineraryLeg compares a stop with the place the traveler arrives from
+type ItineraryLeg struct {
+	From                 string             `json:"from"`
+	To                   string             `json:"to"`
+	Date                 string             `json:"date"` // Arrival date
+	Recommendation       string             `json:"recommendation"`
+	Score                float64            `json:"score"`
+	Reason               string             `json:"reason"`
+	TempDifference       float64            `json:"temp_difference_celsius"`
+	PollutantDifferences map[string]float64 `json:"pollutant_differences"`
+	SharpWorsening       bool               `json:"sharp_worsening"`
+}
+
+// ItinerarySuggestion is a better order for the same stops on the same dates
+type ItinerarySuggestion struct {
+	Stops     []ItineraryStop `json:"stops"`
+	AvgScore  float64         `json:"avg_score"`
+	SharpLegs int             `json:"sharp_legs"`
+	Reason    string          `json:"reason"`
+}
+
+// ItineraryResponse evaluates a multi-stop trip
+type ItineraryResponse struct {
+	CurrentWeather LocationWeather       `json:"current_location"`
+	Origin         *ReverseGeocode       `json:"origin,omitempty"` // Where the current location is
+	AvgScore       float64               `json:"avg_score"`
+	SharpLegs      int                   `json:"sharp_legs"`
+	Stops          []ItineraryStopReport `json:"stops"`
+	Legs           []ItineraryLeg        `json:"legs"`
+	// SuggestedOrder is only set when reordering the stops would help
+	SuggestedOrder *ItinerarySuggestion `json:"suggested_order,omitempty"`
+}
+
+// GroupTripRequest asks which district suits a group traveling from several places
+type GroupTripRequest struct {
+	Origins                  []Location `json:"origins"`
+	DestinationDistrictNames []string   `json:"destination_districts"`
+	TravelDate               string     `json:"travel_date"`            // Format: YYYY-MM-DD
+	OptimizeFor              string     `json:"optimize_for,omitempty"` // average (default) or worst_off
+	Pollutants               []string   `json:"pollutants,omitempty"`
+	TravelerProfile          string     `json:"traveler_profile,omitempty"`
+}
+
+// GroupTraveler is one origin's comparison with a candidate destination
+type GroupTraveler struct {
+	Origin         string  `json:"origin"`
+	Recommendation string  `json:"recommendation"`
+	Score          float64 `json:"score"`
+	Reason         string  `json:"reason"`
+	TempDifference float64 `json:"temp_difference_celsius"`
+}
+
+// GroupDestination is a candidate destination graded for the whole group
+type GroupDestination struct {
+	Rank           int             `json:"rank"`
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Recommendation string          `json:"recommendation"`
+	Score          float64         `json:"score"` // Average or worst-off score, as optimized for
+	AvgScore       float64         `json:"avg_score"`
+	WorstScore     float64         `json:"worst_score"`
+	WorstOffOrigin string          `json:"worst_off_origin"`
+	Destination    LocationWeather `json:"destination"`
+	Travelers      []GroupTraveler `json:"travelers"`
+}
+
+// GroupTripResponse ranks candidate destinations for a group
+type GroupTripResponse struct {
+	TravelDate      string             `json:"travel_date"`
+	OptimizeFor     string             `json:"optimize_for"`
+	BestDestination string             `json:"best_destination"`
+	Reason          string             `json:"reason"`
+	Destinations    []GroupDestination `json:"destinations"`
+}
+
+// DistrictMatch is a district found by a name search
+type DistrictMatch struct {
+	District
+	Matched   string `json:"matched"`    // The name, alias or transliteration that matched
+	MatchType string `json:"match_type"` // exact, alias, transliteration, prefix or fuzzy
+	Distance  int    `json:"distance,omitempty"`
+}
+
+// DistrictSearchResponse lists districts matching a search query
+type DistrictSearchResponse struct {
+	Query      string          `json:"query"`
+	Matches    []DistrictMatch `json:"matches"`
+	DidYouMean []string        `json:"did_you_mean,omitempty"` // Set when nothing matches exactly
+}
+
+// DistrictListResponse is one page of the district catalogue
+type DistrictListResponse struct {
+	Districts  []District `json:"districts"`
+	Page       int        `json:"page"`
+	PerPage    int        `json:"per_page"`
+	Total      int        `json:"total"` // Districts matching the filter, on all pages
+	TotalPages int        `json:"total_pages"`
+}
+
+// DistrictDetails is a district with its upazilas and latest cached weather,
+// if any
+type DistrictDetails struct {
+	District
+	Upazilas         []Upazila        `json:"upazilas,omitempty"`
+	Weather          *DistrictWeather `json:"weather,omitempty"`
+	WeatherUpdatedAt string           `json:"weather_updated_at,omitempty"`
+}
+
+// DivisionSummary aggregates the cached weather of a division's districts
+type DivisionSummary struct {
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	BnName       string          `json:"bn_name"`
+	Districts    int             `json:"districts"` // Districts with weather data
+	AvgTemp2PM   float64         `json:"avg_temp_2pm_celsius"`
+	AvgPM25      float64         `json:"avg_pm25"`
+	BestDistrict DistrictWeather `json:"best_district"`
+}
+
+// DivisionSummaryResponse summarizes every division
+type DivisionSummaryResponse struct {
+	GeneratedAt string            `json:"generated_at"`
+	RankBy      string            `json:"rank_by"` // How each division's best district is chosen
+	Pollutants  []string          `json:"pollutants"`
+	Divisions   []DivisionSummary `json:"divisions"`
+}
+
+// NearbyPlace is a district or upazila close to a point
+type NearbyPlace struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	BnName     string  `json:"bn_name"`
+	DistanceKm float64 `json:"distance_km"` // To its center
+}
+
+// ReverseGeocode names the district, and upazila where known, a point is in
+type ReverseGeocode struct {
+	Lat          float64      `json:"lat"`
+	Long         float64      `json:"long"`
+	Method       string       `json:"method"` // boundary or nearest_center
+	InBangladesh bool         `json:"in_bangladesh"`
+	District     *NearbyPlace `json:"district,omitempty"` // The nearest, even outside Bangladesh
+	Upazila      *NearbyPlace `json:"upazila,omitempty"`  // Only for districts with upazila data
+	// BoundaryDistanceKm is how far outside the nearest district boundary, or
+	// the country outline when there are no district boundaries, the point is
+	BoundaryDistanceKm float64 `json:"boundary_distance_km,omitempty"`
+	Warning            string  `json:"warning,omitempty"`
 }
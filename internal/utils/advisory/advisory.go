@@ -0,0 +1,158 @@
+package advisory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+// Advisory levels, from cleanest to most polluted
+const (
+	LevelWithinGuidelines = "within_guidelines"
+	LevelElevated         = "elevated"
+	LevelHigh             = "high"
+	LevelVeryHigh         = "very_high"
+	// LevelUnavailable is used when no air-quality data covers the date
+	LevelUnavailable = "unavailable"
+)
+
+// Mask recommendation levels
+const (
+	MaskNone        = "none"
+	MaskOptional    = "optional"
+	MaskRecommended = "recommended"
+	MaskRespirator  = "n95_respirator"
+)
+
+// band maps the worst guideline ratio onto advice. The ratio limits line up
+// with the WHO 2021 PM2.5 interim targets: 2.5x is IT-3 (37.5 µg/m³) and 5x
+// is IT-1 (75 µg/m³).
+type band struct {
+	maxRatio        float64
+	level           string
+	generalPublic   string
+	sensitiveGroups string
+	mask            string
+}
+
+var bands = []band{
+	{
+		maxRatio:        1,
+		level:           LevelWithinGuidelines,
+		generalPublic:   "Air quality meets WHO guidelines. No precautions needed.",
+		sensitiveGroups: "No special precautions needed.",
+		mask:            MaskNone,
+	},
+	{
+		maxRatio:        2.5,
+		level:           LevelElevated,
+		generalPublic:   "Air quality is acceptable for most outdoor activities.",
+		sensitiveGroups: "Consider shortening prolonged or heavy outdoor exertion.",
+		mask:            MaskOptional,
+	},
+	{
+		maxRatio:        5,
+		level:           LevelHigh,
+		generalPublic:   "Reduce prolonged or heavy outdoor exertion.",
+		sensitiveGroups: "Avoid prolonged outdoor exertion and keep reliever medication at hand.",
+		mask:            MaskRecommended,
+	},
+	{
+		maxRatio:        math.Inf(1),
+		level:           LevelVeryHigh,
+		generalPublic:   "Avoid outdoor exertion and limit time outside.",
+		sensitiveGroups: "Stay indoors with windows closed where possible.",
+		mask:            MaskRespirator,
+	},
+}
+
+// Assess compares every pollutant against its WHO 2021 guideline value and
+// returns advice for the general public and sensitive groups
+func Assess(p types.Pollutants) types.HealthAdvisory {
+	return AssessScaled(p, 1)
+}
+
+// AssessScaled is Assess with every guideline multiplied by factor, so a
+// factor below 1 gives stricter advice for vulnerable travelers
+func AssessScaled(p types.Pollutants, factor float64) types.HealthAdvisory {
+	if factor <= 0 {
+		factor = 1
+	}
+
+	var exceedances []types.GuidelineExceedance
+	worst := 0.0
+
+	for _, key := range pollutant.Keys {
+		guideline := pollutant.ReferenceLevel(key) * factor
+		concentration := pollutant.Value(p, key)
+		ratio := concentration / guideline
+
+		if ratio > worst {
+			worst = ratio
+		}
+		if ratio > 1 {
+			exceedances = append(exceedances, types.GuidelineExceedance{
+				Pollutant:     key,
+				Concentration: concentration,
+				Guideline:     guideline,
+				Ratio:         math.Round(ratio*100) / 100,
+			})
+		}
+	}
+
+	// Worst offender first
+	sort.Slice(exceedances, func(i, j int) bool {
+		return exceedances[i].Ratio > exceedances[j].Ratio
+	})
+
+	b := bands[len(bands)-1]
+	for _, candidate := range bands {
+		if worst <= candidate.maxRatio {
+			b = candidate
+			break
+		}
+	}
+
+	return types.HealthAdvisory{
+		Level:              b.level,
+		GeneralPublic:      b.generalPublic,
+		SensitiveGroups:    b.sensitiveGroups,
+		MaskRecommendation: b.mask,
+		Summary:            summarize(exceedances, factor),
+		Exceedances:        exceedances,
+	}
+}
+
+// Unavailable returns the advisory for a date without air-quality data
+func Unavailable() types.HealthAdvisory {
+	return types.HealthAdvisory{
+		Level:           LevelUnavailable,
+		GeneralPublic:   "No air-quality data is available for this date.",
+		SensitiveGroups: "Check local air-quality reports before spending long periods outdoors.",
+		Summary:         "Air-quality data is not available for this date.",
+		Exceedances:     []types.GuidelineExceedance{},
+	}
+}
+
+// summarize describes guideline exceedances in one sentence
+func summarize(exceedances []types.GuidelineExceedance, factor float64) string {
+	if len(exceedances) == 0 {
+		return "All pollutants are within WHO guidelines."
+	}
+
+	parts := make([]string, 0, len(exceedances))
+	for _, e := range exceedances {
+		parts = append(parts, fmt.Sprintf("%s is %.1fx", pollutant.Label(e.Pollutant), e.Ratio))
+	}
+
+	guideline := "the WHO guideline"
+	if factor != 1 {
+		guideline = fmt.Sprintf("the WHO guideline adjusted to %.0f%%", factor*100)
+	}
+
+	return fmt.Sprintf("%s %s.", strings.Join(parts, ", "), guideline)
+}
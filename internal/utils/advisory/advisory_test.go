@@ -0,0 +1,75 @@
+package advisory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name                string
+		pollutants          types.Pollutants
+		expectedLevel       string
+		expectedMask        string
+		expectedExceedances []string
+		summaryContains     string
+	}{
+		{
+			name:            "clean air is within guidelines",
+			pollutants:      types.Pollutants{PM25: 10, PM10: 30, NO2: 10, O3: 60, SO2: 5, CO: 300},
+			expectedLevel:   LevelWithinGuidelines,
+			expectedMask:    MaskNone,
+			summaryContains: "within WHO guidelines",
+		},
+		{
+			name:                "moderate PM2.5 is elevated",
+			pollutants:          types.Pollutants{PM25: 30, PM10: 30},
+			expectedLevel:       LevelElevated,
+			expectedMask:        MaskOptional,
+			expectedExceedances: []string{"pm25"},
+			summaryContains:     "PM2.5 is 2.0x",
+		},
+		{
+			name:                "worst pollutant decides the level",
+			pollutants:          types.Pollutants{PM25: 20, NO2: 100},
+			expectedLevel:       LevelHigh,
+			expectedMask:        MaskRecommended,
+			expectedExceedances: []string{"no2", "pm25"},
+			summaryContains:     "NO2 is 4.0x",
+		},
+		{
+			name:                "beyond interim target 1 needs a respirator",
+			pollutants:          types.Pollutants{PM25: 120},
+			expectedLevel:       LevelVeryHigh,
+			expectedMask:        MaskRespirator,
+			expectedExceedances: []string{"pm25"},
+			summaryContains:     "PM2.5 is 8.0x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Assess(tt.pollutants)
+
+			if result.Level != tt.expectedLevel {
+				t.Errorf("expected level '%s', got '%s'", tt.expectedLevel, result.Level)
+			}
+			if result.MaskRecommendation != tt.expectedMask {
+				t.Errorf("expected mask '%s', got '%s'", tt.expectedMask, result.MaskRecommendation)
+			}
+			if len(result.Exceedances) != len(tt.expectedExceedances) {
+				t.Fatalf("expected %d exceedances, got %d", len(tt.expectedExceedances), len(result.Exceedances))
+			}
+			for i, key := range tt.expectedExceedances {
+				if result.Exceedances[i].Pollutant != key {
+					t.Errorf("at position %d: expected %s, got %s", i, key, result.Exceedances[i].Pollutant)
+				}
+			}
+			if !strings.Contains(result.Summary, tt.summaryContains) {
+				t.Errorf("expected summary to contain '%s', got: %s", tt.summaryContains, result.Summary)
+			}
+		})
+	}
+}
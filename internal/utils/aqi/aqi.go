@@ -0,0 +1,276 @@
+package aqi
+
+import (
+	"math"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/pollutant"
+)
+
+// Supported AQI standards
+const (
+	USEPA         = "us_epa"
+	BangladeshDoE = "bd_doe"
+)
+
+// Molecular weights (g/mol) used to convert gases from µg/m³ to ppb at 25°C
+const (
+	molarVolume = 24.45
+	mwNO2       = 46.01
+	mwO3        = 48.00
+	mwSO2       = 64.07
+	mwCO        = 28.01
+)
+
+// breakpoint maps a concentration range onto an index range
+type breakpoint struct {
+	cLow, cHigh float64
+	iLow, iHigh int
+}
+
+// category describes one band of an AQI scale
+type category struct {
+	max   int
+	label string
+	color string
+}
+
+// scale is a complete AQI definition: per-pollutant breakpoints in the
+// units the standard uses, plus the category bands
+type scale struct {
+	breakpoints map[string][]breakpoint
+	categories  []category
+}
+
+// Gaseous pollutant breakpoints from the US EPA technical assistance document.
+// O3 uses the 8-hour table, extended to the 1-hour hazardous ceiling above 0.200 ppm.
+var (
+	o3Breakpoints = []breakpoint{ // ppm
+		{0.000, 0.054, 0, 50},
+		{0.055, 0.070, 51, 100},
+		{0.071, 0.085, 101, 150},
+		{0.086, 0.105, 151, 200},
+		{0.106, 0.200, 201, 300},
+		{0.201, 0.604, 301, 500},
+	}
+	no2Breakpoints = []breakpoint{ // ppb
+		{0, 53, 0, 50},
+		{54, 100, 51, 100},
+		{101, 360, 101, 150},
+		{361, 649, 151, 200},
+		{650, 1249, 201, 300},
+		{1250, 2049, 301, 500},
+	}
+	so2Breakpoints = []breakpoint{ // ppb
+		{0, 35, 0, 50},
+		{36, 75, 51, 100},
+		{76, 185, 101, 150},
+		{186, 304, 151, 200},
+		{305, 604, 201, 300},
+		{605, 1004, 301, 500},
+	}
+	coBreakpoints = []breakpoint{ // ppm
+		{0.0, 4.4, 0, 50},
+		{4.5, 9.4, 51, 100},
+		{9.5, 12.4, 101, 150},
+		{12.5, 15.4, 151, 200},
+		{15.5, 30.4, 201, 300},
+		{30.5, 50.4, 301, 500},
+	}
+	pm10Breakpoints = []breakpoint{ // µg/m³
+		{0, 54, 0, 50},
+		{55, 154, 51, 100},
+		{155, 254, 101, 150},
+		{255, 354, 151, 200},
+		{355, 424, 201, 300},
+		{425, 604, 301, 500},
+	}
+)
+
+// averagingHours is the period each pollutant's breakpoints are defined for:
+// 24-hour PM, 8-hour O3 and CO, and 1-hour NO2 and SO2
+var averagingHours = map[string]int{
+	pollutant.PM25: 24,
+	pollutant.PM10: 24,
+	pollutant.O3:   8,
+	pollutant.CO:   8,
+	pollutant.NO2:  1,
+	pollutant.SO2:  1,
+}
+
+// AveragingHours returns the averaging period in hours a pollutant's
+// concentration must cover before its breakpoints apply; 1 for unknown keys
+func AveragingHours(key string) int {
+	if hours, ok := averagingHours[key]; ok {
+		return hours
+	}
+	return 1
+}
+
+var scales = map[string]scale{
+	// US EPA AQI with the 2024 PM2.5 revision
+	USEPA: {
+		breakpoints: map[string][]breakpoint{
+			pollutant.PM25: { // µg/m³
+				{0.0, 9.0, 0, 50},
+				{9.1, 35.4, 51, 100},
+				{35.5, 55.4, 101, 150},
+				{55.5, 125.4, 151, 200},
+				{125.5, 225.4, 201, 300},
+				{225.5, 325.4, 301, 500},
+			},
+			pollutant.PM10: pm10Breakpoints,
+			pollutant.O3:   o3Breakpoints,
+			pollutant.NO2:  no2Breakpoints,
+			pollutant.SO2:  so2Breakpoints,
+			pollutant.CO:   coBreakpoints,
+		},
+		categories: []category{
+			{50, "Good", "#00E400"},
+			{100, "Moderate", "#FFFF00"},
+			{150, "Unhealthy for Sensitive Groups", "#FF7E00"},
+			{200, "Unhealthy", "#FF0000"},
+			{300, "Very Unhealthy", "#8F3F97"},
+			{500, "Hazardous", "#7E0023"},
+		},
+	},
+	// Bangladesh DoE AQI follows the EPA method, with the PM2.5 "100" breakpoint
+	// anchored to the national 24-hour standard of 65 µg/m³. The DoE publishes
+	// no tables of its own for the other pollutants, so they reuse the EPA's.
+	BangladeshDoE: {
+		breakpoints: map[string][]breakpoint{
+			pollutant.PM25: { // µg/m³
+				{0.0, 15.4, 0, 50},
+				{15.5, 65.4, 51, 100},
+				{65.5, 150.4, 101, 150},
+				{150.5, 250.4, 151, 200},
+				{250.5, 350.4, 201, 300},
+				{350.5, 500.4, 301, 500},
+			},
+			pollutant.PM10: pm10Breakpoints,
+			pollutant.O3:   o3Breakpoints,
+			pollutant.NO2:  no2Breakpoints,
+			pollutant.SO2:  so2Breakpoints,
+			pollutant.CO:   coBreakpoints,
+		},
+		categories: []category{
+			{50, "Good", "#009900"},
+			{100, "Moderate", "#BAFF00"},
+			{150, "Caution", "#FFFF00"},
+			{200, "Unhealthy", "#FF7700"},
+			{300, "Very Unhealthy", "#FF0000"},
+			{500, "Extremely Unhealthy", "#990000"},
+		},
+	},
+}
+
+// Calculate computes the overall AQI for a standard from pollutant
+// concentrations in µg/m³, each averaged over its AveragingHours. The overall
+// value is the highest sub-index and that pollutant is reported as dominant.
+func Calculate(standard string, p types.Pollutants) types.AQI {
+	result := types.AQI{SubIndices: make(map[string]int)}
+
+	for _, key := range pollutant.Keys {
+		index, ok := SubIndex(standard, key, pollutant.Value(p, key))
+		if !ok {
+			continue
+		}
+		result.SubIndices[key] = index
+		if result.DominantPollutant == "" || index > result.Value {
+			result.Value = index
+			result.DominantPollutant = key
+		}
+	}
+
+	result.Category, result.Color = Category(standard, result.Value)
+
+	return result
+}
+
+// Report computes the AQI under every supported standard
+func Report(p types.Pollutants) types.AQIReport {
+	return types.AQIReport{
+		USEPA:         Calculate(USEPA, p),
+		BangladeshDoE: Calculate(BangladeshDoE, p),
+	}
+}
+
+// SubIndex converts one pollutant concentration (µg/m³) into its index value.
+// Concentrations above the top breakpoint are capped at 500. Returns false for
+// an unknown standard or pollutant.
+func SubIndex(standard, key string, concentration float64) (int, bool) {
+	sc, ok := scales[standard]
+	if !ok {
+		return 0, false
+	}
+	bps, ok := sc.breakpoints[key]
+	if !ok {
+		return 0, false
+	}
+
+	c := truncate(key, toStandardUnits(key, concentration))
+	if c < 0 {
+		c = 0
+	}
+
+	for _, bp := range bps {
+		if c <= bp.cHigh {
+			// Truncation can leave c in the gap between two bands; it belongs to the upper one
+			if c < bp.cLow {
+				c = bp.cLow
+			}
+			index := float64(bp.iHigh-bp.iLow)/(bp.cHigh-bp.cLow)*(c-bp.cLow) + float64(bp.iLow)
+			return int(math.Round(index)), true
+		}
+	}
+
+	return bps[len(bps)-1].iHigh, true
+}
+
+// Category returns the label and hex colour for an index value
+func Category(standard string, value int) (string, string) {
+	sc, ok := scales[standard]
+	if !ok || len(sc.categories) == 0 {
+		return "", ""
+	}
+
+	for _, cat := range sc.categories {
+		if value <= cat.max {
+			return cat.label, cat.color
+		}
+	}
+
+	last := sc.categories[len(sc.categories)-1]
+	return last.label, last.color
+}
+
+// toStandardUnits converts µg/m³ into the units the breakpoint tables use
+func toStandardUnits(key string, ugm3 float64) float64 {
+	switch key {
+	case pollutant.NO2:
+		return ugm3 * molarVolume / mwNO2
+	case pollutant.SO2:
+		return ugm3 * molarVolume / mwSO2
+	case pollutant.O3:
+		return ugm3 * molarVolume / mwO3 / 1000
+	case pollutant.CO:
+		return ugm3 * molarVolume / mwCO / 1000
+	default:
+		return ugm3
+	}
+}
+
+// truncate applies the EPA reporting precision for each pollutant
+func truncate(key string, c float64) float64 {
+	var step float64
+	switch key {
+	case pollutant.PM25, pollutant.CO:
+		step = 0.1
+	case pollutant.O3:
+		step = 0.001
+	default:
+		step = 1
+	}
+	// The small epsilon keeps values like 35.4 from truncating to 35.3
+	return math.Floor(c/step+1e-9) * step
+}
@@ -0,0 +1,113 @@
+package aqi
+
+import (
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// TestSubIndex tests the breakpoint interpolation for each pollutant
+func TestSubIndex(t *testing.T) {
+	tests := []struct {
+		name          string
+		standard      string
+		pollutant     string
+		concentration float64
+		expected      int
+	}{
+		{name: "US PM2.5 zero", standard: USEPA, pollutant: "pm25", concentration: 0, expected: 0},
+		{name: "US PM2.5 top of good", standard: USEPA, pollutant: "pm25", concentration: 9.0, expected: 50},
+		{name: "US PM2.5 bottom of moderate", standard: USEPA, pollutant: "pm25", concentration: 9.1, expected: 51},
+		{name: "US PM2.5 mid moderate", standard: USEPA, pollutant: "pm25", concentration: 12.0, expected: 56},
+		{name: "US PM2.5 top of moderate", standard: USEPA, pollutant: "pm25", concentration: 35.4, expected: 100},
+		{name: "US PM2.5 truncates before lookup", standard: USEPA, pollutant: "pm25", concentration: 35.49, expected: 100},
+		{name: "US PM2.5 bottom of sensitive groups", standard: USEPA, pollutant: "pm25", concentration: 35.5, expected: 101},
+		{name: "US PM2.5 above scale is capped", standard: USEPA, pollutant: "pm25", concentration: 600, expected: 500},
+		{name: "BD PM2.5 national standard", standard: BangladeshDoE, pollutant: "pm25", concentration: 65.4, expected: 100},
+		{name: "BD PM2.5 mid moderate", standard: BangladeshDoE, pollutant: "pm25", concentration: 40, expected: 75},
+		{name: "PM10 mid moderate", standard: USEPA, pollutant: "pm10", concentration: 100, expected: 73},
+		{name: "NO2 converted to ppb", standard: USEPA, pollutant: "no2", concentration: 100, expected: 50},
+		{name: "O3 converted to ppm", standard: USEPA, pollutant: "o3", concentration: 100, expected: 46},
+		{name: "SO2 converted to ppb", standard: USEPA, pollutant: "so2", concentration: 100, expected: 54},
+		{name: "CO converted to ppm", standard: USEPA, pollutant: "co", concentration: 5000, expected: 49},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := SubIndex(tt.standard, tt.pollutant, tt.concentration)
+			if !ok {
+				t.Fatalf("expected sub-index for %s/%s", tt.standard, tt.pollutant)
+			}
+			if index != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, index)
+			}
+		})
+	}
+}
+
+// TestSubIndexUnknown verifies unknown standards and pollutants are rejected
+func TestSubIndexUnknown(t *testing.T) {
+	if _, ok := SubIndex("eu_caqi", "pm25", 10); ok {
+		t.Error("expected unknown standard to be rejected")
+	}
+	if _, ok := SubIndex(USEPA, "radon", 10); ok {
+		t.Error("expected unknown pollutant to be rejected")
+	}
+}
+
+// TestCategory tests the category bands for both standards
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		standard      string
+		value         int
+		expectedLabel string
+		expectedColor string
+	}{
+		{USEPA, 0, "Good", "#00E400"},
+		{USEPA, 51, "Moderate", "#FFFF00"},
+		{USEPA, 150, "Unhealthy for Sensitive Groups", "#FF7E00"},
+		{USEPA, 301, "Hazardous", "#7E0023"},
+		{BangladeshDoE, 120, "Caution", "#FFFF00"},
+		{BangladeshDoE, 500, "Extremely Unhealthy", "#990000"},
+	}
+
+	for _, tt := range tests {
+		label, color := Category(tt.standard, tt.value)
+		if label != tt.expectedLabel || color != tt.expectedColor {
+			t.Errorf("%s %d: expected %s/%s, got %s/%s", tt.standard, tt.value, tt.expectedLabel, tt.expectedColor, label, color)
+		}
+	}
+}
+
+// TestCalculate tests the overall index and dominant pollutant
+func TestCalculate(t *testing.T) {
+	p := types.Pollutants{PM25: 40, PM10: 100, O3: 100}
+
+	tests := []struct {
+		standard         string
+		expectedValue    int
+		expectedCategory string
+	}{
+		{USEPA, 112, "Unhealthy for Sensitive Groups"},
+		{BangladeshDoE, 75, "Moderate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.standard, func(t *testing.T) {
+			result := Calculate(tt.standard, p)
+
+			if result.Value != tt.expectedValue {
+				t.Errorf("expected value %d, got %d", tt.expectedValue, result.Value)
+			}
+			if result.Category != tt.expectedCategory {
+				t.Errorf("expected category '%s', got '%s'", tt.expectedCategory, result.Category)
+			}
+			if result.DominantPollutant != "pm25" {
+				t.Errorf("expected dominant pollutant 'pm25', got '%s'", result.DominantPollutant)
+			}
+			if result.SubIndices["pm10"] != 73 {
+				t.Errorf("expected pm10 sub-index 73, got %d", result.SubIndices["pm10"])
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// earthRadiusKm is the mean Earth radius
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle (haversine) distance between two points
+func DistanceKm(a, b types.Location) float64 {
+	return earthRadiusKm * centralAngle(a, b)
+}
+
+// Intermediate returns the point a fraction f of the way from a to b along
+// the great circle
+func Intermediate(a, b types.Location, f float64) types.Location {
+	d := centralAngle(a, b)
+	if d == 0 {
+		return types.Location{Lat: a.Lat, Long: a.Long}
+	}
+
+	lat1, long1 := radians(a.Lat), radians(a.Long)
+	lat2, long2 := radians(b.Lat), radians(b.Long)
+
+	wa := math.Sin((1-f)*d) / math.Sin(d)
+	wb := math.Sin(f*d) / math.Sin(d)
+	x := wa*math.Cos(lat1)*math.Cos(long1) + wb*math.Cos(lat2)*math.Cos(long2)
+	y := wa*math.Cos(lat1)*math.Sin(long1) + wb*math.Cos(lat2)*math.Sin(long2)
+	z := wa*math.Sin(lat1) + wb*math.Sin(lat2)
+
+	return types.Location{
+		Lat:  degrees(math.Atan2(z, math.Hypot(x, y))),
+		Long: degrees(math.Atan2(y, x)),
+	}
+}
+
+// PathPoint is a point on a path and its distance from the start
+type PathPoint struct {
+	types.Location
+	DistanceKm float64
+}
+
+// Sample returns n points evenly spaced by distance along a path of
+// great-circle segments, including both ends. Paths need at least two points
+// and n at least two.
+func Sample(path []types.Location, n int) []PathPoint {
+	if len(path) < 2 || n < 2 {
+		return nil
+	}
+
+	// Cumulative distance at each vertex
+	cumulative := make([]float64, len(path))
+	for i := 1; i < len(path); i++ {
+		cumulative[i] = cumulative[i-1] + DistanceKm(path[i-1], path[i])
+	}
+	total := cumulative[len(cumulative)-1]
+
+	points := make([]PathPoint, 0, n)
+	segment := 1
+	for i := 0; i < n; i++ {
+		target := total * float64(i) / float64(n-1)
+		for segment < len(path)-1 && cumulative[segment] < target {
+			segment++
+		}
+
+		var loc types.Location
+		length := cumulative[segment] - cumulative[segment-1]
+		if length == 0 {
+			loc = types.Location{Lat: path[segment].Lat, Long: path[segment].Long}
+		} else {
+			f := math.Min(1, (target-cumulative[segment-1])/length)
+			loc = Intermediate(path[segment-1], path[segment], f)
+		}
+		points = append(points, PathPoint{Location: loc, DistanceKm: target})
+	}
+	return points
+}
+
+// centralAngle is the angle between two points seen from Earth's center
+func centralAngle(a, b types.Location) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLong := radians(b.Long - a.Long)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
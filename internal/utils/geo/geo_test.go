@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+var (
+	dhaka     = types.Location{Lat: 23.8103, Long: 90.4125}
+	coxsBazar = types.Location{Lat: 21.4272, Long: 92.0058}
+)
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     types.Location
+		expected float64
+	}{
+		{"same point", dhaka, dhaka, 0},
+		{"Dhaka to Cox's Bazar", dhaka, coxsBazar, 311.4},
+		{"one degree of latitude", types.Location{Lat: 0}, types.Location{Lat: 1}, 111.19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DistanceKm(tt.a, tt.b); math.Abs(got-tt.expected) > 0.5 {
+				t.Errorf("expected %.2f km, got %.2f km", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	t.Run("great circle", func(t *testing.T) {
+		points := Sample([]types.Location{dhaka, coxsBazar}, 5)
+		if len(points) != 5 {
+			t.Fatalf("expected 5 points, got %d", len(points))
+		}
+		if DistanceKm(points[0].Location, dhaka) > 0.01 || DistanceKm(points[4].Location, coxsBazar) > 0.01 {
+			t.Errorf("expected the ends to be kept, got %+v and %+v", points[0], points[4])
+		}
+
+		total := DistanceKm(dhaka, coxsBazar)
+		mid := points[2]
+		if math.Abs(mid.DistanceKm-total/2) > 0.01 || math.Abs(DistanceKm(dhaka, mid.Location)-total/2) > 0.5 {
+			t.Errorf("expected the middle point halfway, got %.2f km", mid.DistanceKm)
+		}
+	})
+
+	t.Run("polyline", func(t *testing.T) {
+		path := []types.Location{{Lat: 0, Long: 0}, {Lat: 1, Long: 0}, {Lat: 1, Long: 1}}
+		points := Sample(path, 3)
+		// The halfway point of two equal legs is the corner
+		if DistanceKm(points[1].Location, path[1]) > 0.5 {
+			t.Errorf("expected the middle point at the corner, got %+v", points[1])
+		}
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		if points := Sample([]types.Location{dhaka}, 5); points != nil {
+			t.Errorf("expected no points, got %d", len(points))
+		}
+	})
+}
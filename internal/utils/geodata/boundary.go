@@ -0,0 +1,283 @@
+package geodata
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geo"
+)
+
+// gridCellDegrees is the size of a spatial index cell, about 25 km
+const gridCellDegrees = 0.25
+
+// Boundaries holds boundary polygons, one set per district or, for the
+// country outline, a single one. A grid index lists the boundaries whose
+// bounding box overlaps each cell, so a point is only tested against the few
+// around it.
+type Boundaries struct {
+	districts []boundary
+	cells     map[cell][]int // Indexes into districts
+}
+
+// boundary is one district's polygons. districtID is the feature's district
+// ID or, for features that only have a name, the district name.
+type boundary struct {
+	districtID string
+	polygons   []polygon
+	bounds     bbox
+}
+
+// polygon is an exterior ring followed by any holes. Rings are closed: the
+// last point repeats the first.
+type polygon [][]point
+
+type point struct{ long, lat float64 }
+
+type bbox struct{ minLong, minLat, maxLong, maxLat float64 }
+
+type cell struct{ x, y int }
+
+// GeoJSON as far as district boundaries need it
+type featureCollection struct {
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	ID         json.RawMessage `json:"id"`
+	Properties struct {
+		ID        json.RawMessage `json:"id"`
+		ShapeName string          `json:"shapeName"` // geoBoundaries
+		Name      string          `json:"name"`
+	} `json:"properties"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// ParseBoundaries reads a GeoJSON FeatureCollection of district boundaries.
+// Each feature is a Polygon or MultiPolygon whose "id" property (or feature
+// id) is the district ID from districts.json; coordinates are [long, lat].
+// Features without an id, such as geoBoundaries', are keyed by their
+// "shapeName" or "name" property instead, which Index resolves like a
+// district name.
+func ParseBoundaries(r io.Reader) (*Boundaries, error) {
+	var fc featureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+
+	b := &Boundaries{cells: make(map[cell][]int)}
+	for i, f := range fc.Features {
+		id := featureID(f.Properties.ID)
+		if id == "" {
+			id = featureID(f.ID)
+		}
+		if id == "" {
+			id = cmp.Or(strings.TrimSpace(f.Properties.ShapeName), strings.TrimSpace(f.Properties.Name))
+		}
+		if id == "" {
+			return nil, fmt.Errorf("feature %d: no district id or name", i)
+		}
+
+		var polygons [][][][]float64
+		var err error
+		switch f.Geometry.Type {
+		case "Polygon":
+			var coordinates [][][]float64
+			err = json.Unmarshal(f.Geometry.Coordinates, &coordinates)
+			polygons = [][][][]float64{coordinates}
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygons)
+		default:
+			return nil, fmt.Errorf("feature %d (district %s): unsupported geometry type %q", i, id, f.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("feature %d (district %s): %w", i, id, err)
+		}
+
+		d, err := newBoundary(id, polygons)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d (district %s): %w", i, id, err)
+		}
+		b.add(d)
+	}
+	return b, nil
+}
+
+// featureID reads an id given as a JSON string or number
+func featureID(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
+func newBoundary(id string, coordinates [][][][]float64) (boundary, error) {
+	d := boundary{
+		districtID: id,
+		bounds:     bbox{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)},
+	}
+	for _, rings := range coordinates {
+		if len(rings) == 0 {
+			return boundary{}, fmt.Errorf("polygon without rings")
+		}
+
+		p := make(polygon, len(rings))
+		for i, ring := range rings {
+			if len(ring) < 4 {
+				return boundary{}, fmt.Errorf("ring with %d positions, need at least 4", len(ring))
+			}
+			p[i] = make([]point, len(ring))
+			for j, pos := range ring {
+				if len(pos) < 2 {
+					return boundary{}, fmt.Errorf("position without long and lat")
+				}
+				p[i][j] = point{long: pos[0], lat: pos[1]}
+				d.bounds.extend(p[i][j])
+			}
+			// Close rings left open
+			if p[i][0] != p[i][len(ring)-1] {
+				p[i] = append(p[i], p[i][0])
+			}
+		}
+		d.polygons = append(d.polygons, p)
+	}
+	if len(d.polygons) == 0 {
+		return boundary{}, fmt.Errorf("no polygons")
+	}
+	return d, nil
+}
+
+// add indexes a district under every cell its bounding box overlaps
+func (b *Boundaries) add(d boundary) {
+	i := len(b.districts)
+	b.districts = append(b.districts, d)
+
+	from, to := cellOf(d.bounds.minLat, d.bounds.minLong), cellOf(d.bounds.maxLat, d.bounds.maxLong)
+	for x := from.x; x <= to.x; x++ {
+		for y := from.y; y <= to.y; y++ {
+			c := cell{x, y}
+			b.cells[c] = append(b.cells[c], i)
+		}
+	}
+}
+
+// Contains returns the ID, or name, of the district whose boundary contains
+// the point
+func (b *Boundaries) Contains(lat, long float64) (string, bool) {
+	p := point{long: long, lat: lat}
+	for _, i := range b.cells[cellOf(lat, long)] {
+		if d := b.districts[i]; d.bounds.contains(p) && d.contains(p) {
+			return d.districtID, true
+		}
+	}
+	return "", false
+}
+
+// Nearest returns the district whose boundary is closest to the point and
+// the distance to it; 0 for points inside a district
+func (b *Boundaries) Nearest(lat, long float64) (string, float64) {
+	if id, ok := b.Contains(lat, long); ok {
+		return id, 0
+	}
+
+	p := point{long: long, lat: lat}
+	nearestID, nearestKm := "", math.Inf(1)
+	for _, d := range b.districts {
+		// The bounding box is never farther than the boundary
+		if distanceKm(p, d.bounds.clamp(p)) >= nearestKm {
+			continue
+		}
+		for _, poly := range d.polygons {
+			for _, ring := range poly {
+				for i := 1; i < len(ring); i++ {
+					if km := distanceKm(p, closestOnSegment(p, ring[i-1], ring[i])); km < nearestKm {
+						nearestID, nearestKm = d.districtID, km
+					}
+				}
+			}
+		}
+	}
+	return nearestID, nearestKm
+}
+
+// Len is the number of boundaries
+func (b *Boundaries) Len() int {
+	return len(b.districts)
+}
+
+// contains tests the point against each polygon by ray casting. Crossing
+// a hole's edge counts like crossing the exterior, so holes are excluded.
+func (d boundary) contains(p point) bool {
+	for _, poly := range d.polygons {
+		inside := false
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				a, c := ring[i-1], ring[i]
+				if (a.lat > p.lat) != (c.lat > p.lat) &&
+					p.long < a.long+(p.lat-a.lat)*(c.long-a.long)/(c.lat-a.lat) {
+					inside = !inside
+				}
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+func cellOf(lat, long float64) cell {
+	return cell{
+		x: int(math.Floor(long / gridCellDegrees)),
+		y: int(math.Floor(lat / gridCellDegrees)),
+	}
+}
+
+func (b *bbox) extend(p point) {
+	b.minLong, b.maxLong = min(b.minLong, p.long), max(b.maxLong, p.long)
+	b.minLat, b.maxLat = min(b.minLat, p.lat), max(b.maxLat, p.lat)
+}
+
+func (b bbox) contains(p point) bool {
+	return p.long >= b.minLong && p.long <= b.maxLong && p.lat >= b.minLat && p.lat <= b.maxLat
+}
+
+// clamp returns the point of the box closest to p
+func (b bbox) clamp(p point) point {
+	return point{
+		long: min(max(p.long, b.minLong), b.maxLong),
+		lat:  min(max(p.lat, b.minLat), b.maxLat),
+	}
+}
+
+// closestOnSegment finds the point of segment ab closest to p, treating
+// degrees as flat with longitude scaled by the cosine of p's latitude. That
+// is accurate enough over the few kilometres that matter near a border.
+func closestOnSegment(p, a, b point) point {
+	k := math.Cos(p.lat * math.Pi / 180)
+	ax, ay := (a.long-p.long)*k, a.lat-p.lat
+	bx, by := (b.long-p.long)*k, b.lat-p.lat
+	dx, dy := bx-ax, by-ay
+
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = min(max(-(ax*dx+ay*dy)/l, 0), 1)
+	}
+	return point{long: a.long + t*(b.long-a.long), lat: a.lat + t*(b.lat-a.lat)}
+}
+
+func distanceKm(a, b point) float64 {
+	return geo.DistanceKm(types.Location{Lat: a.lat, Long: a.long}, types.Location{Lat: b.lat, Long: b.long})
+}
@@ -0,0 +1,76 @@
+package geodata
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// Two districts: "1" is a square with a square hole, 2 is a square plus an
+// island and has a numeric feature id
+const testBoundaries = `{
+	"type": "FeatureCollection",
+	"features": [
+		{"type": "Feature", "properties": {"id": "1"}, "geometry": {"type": "Polygon", "coordinates": [
+			[[90, 23], [91, 23], [91, 24], [90, 24], [90, 23]],
+			[[90.4, 23.4], [90.4, 23.6], [90.6, 23.6], [90.6, 23.4], [90.4, 23.4]]
+		]}},
+		{"type": "Feature", "id": 2, "properties": {}, "geometry": {"type": "MultiPolygon", "coordinates": [
+			[[[91, 23], [92, 23], [92, 24], [91, 24], [91, 23]]],
+			[[[91.5, 22], [91.6, 22], [91.6, 22.1], [91.5, 22.1]]]
+		]}}
+	]
+}`
+
+func TestBoundaries(t *testing.T) {
+	b, err := ParseBoundaries(strings.NewReader(testBoundaries))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		lat, long  float64
+		expected   string
+		distanceKm float64
+	}{
+		{"inside", 23.5, 90.2, "1", 0},
+		{"in the hole", 23.5, 90.5, "1", 10.2},
+		{"second district", 23.5, 91.5, "2", 0},
+		{"island left open", 22.05, 91.55, "2", 0},
+		{"west of both", 23.5, 89.9, "1", 10.2},
+		{"far north", 25, 91.2, "2", 111.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := b.Contains(tt.lat, tt.long)
+			if inside := tt.distanceKm == 0; ok != inside || (inside && id != tt.expected) {
+				t.Errorf("expected containment in %q to be %v, got %q (%v)", tt.expected, inside, id, ok)
+			}
+
+			id, km := b.Nearest(tt.lat, tt.long)
+			if id != tt.expected || math.Abs(km-tt.distanceKm) > 0.1 {
+				t.Errorf("expected %s at %.1f km, got %s at %.1f km", tt.expected, tt.distanceKm, id, km)
+			}
+		})
+	}
+
+	t.Run("invalid features", func(t *testing.T) {
+		tests := []struct {
+			feature   string
+			errSubstr string
+		}{
+			{`{"properties": {}, "geometry": {"type": "Polygon", "coordinates": []}}`, "no district id"},
+			{`{"properties": {"id": "1"}, "geometry": {"type": "Point", "coordinates": [90, 23]}}`, "unsupported geometry type"},
+			{`{"properties": {"id": "1"}, "geometry": {"type": "Polygon", "coordinates": [[[90, 23], [91, 23], [90, 23]]]}}`, "need at least 4"},
+		}
+
+		for _, tt := range tests {
+			_, err := ParseBoundaries(strings.NewReader(`{"features": [` + tt.feature + `]}`))
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("expected error containing %q, got %v", tt.errSubstr, err)
+			}
+		}
+	})
+}
@@ -10,9 +10,22 @@ import (
 )
 
 var (
-	data     []types.District
-	loadOnce sync.Once
-	loadErr  error
+	data      []types.District
+	divisions []types.Division
+	loadOnce  sync.Once
+	loadErr   error
+
+	upazilas        []types.Upazila
+	loadUpazilaOnce sync.Once
+	loadUpazilaErr  error
+
+	boundaries       *Boundaries
+	loadBoundaryOnce sync.Once
+	loadBoundaryErr  error
+
+	country         *Boundaries
+	loadCountryOnce sync.Once
+	loadCountryErr  error
 )
 
 // Load reads the JSON file once. Safe to call multiple times.
@@ -31,32 +44,141 @@ func Load(filepath string) error {
 			return
 		}
 
+		divisions = raw.Divisions
+		divisionNames := make(map[string]string, len(divisions))
+		for _, d := range divisions {
+			divisionNames[d.ID] = d.Name
+		}
+
 		// Convert to weather.District with parsed coordinates
 		data = make([]types.District, 0, len(raw.Districts))
 		for _, d := range raw.Districts {
-			lat, err := strconv.ParseFloat(d.Lat, 64)
+			lat, long, err := parseCoordinates(d.Lat, d.Long)
 			if err != nil {
 				continue
 			}
-			long, err := strconv.ParseFloat(d.Long, 64)
+
+			data = append(data, types.District{
+				ID:           d.ID,
+				DivisionID:   d.DivisionID,
+				DivisionName: divisionNames[d.DivisionID],
+				Name:         d.Name,
+				BnName:       d.BnName,
+				Lat:          lat,
+				Long:         long,
+			})
+		}
+	})
+
+	return loadErr
+}
+
+func Districts() []types.District {
+	return data
+}
+
+// Divisions returns the divisions loaded alongside the districts
+func Divisions() []types.Division {
+	return divisions
+}
+
+// LoadUpazilas reads the upazila JSON file once. Safe to call multiple times.
+// Upazilas are linked to their districts when indexed.
+func LoadUpazilas(filepath string) error {
+	loadUpazilaOnce.Do(func() {
+		file, err := os.Open(filepath)
+		if err != nil {
+			loadUpazilaErr = err
+			return
+		}
+		defer file.Close()
+
+		var raw types.GeoData
+		if err := json.NewDecoder(file).Decode(&raw); err != nil {
+			loadUpazilaErr = err
+			return
+		}
+
+		upazilas = make([]types.Upazila, 0, len(raw.Upazilas))
+		for _, u := range raw.Upazilas {
+			lat, long, err := parseCoordinates(u.Lat, u.Long)
 			if err != nil {
 				continue
 			}
 
-			data = append(data, types.District{
-				ID:         d.ID,
-				DivisionID: d.DivisionID,
-				Name:       d.Name,
-				BnName:     d.BnName,
+			upazilas = append(upazilas, types.Upazila{
+				ID:         u.ID,
+				DistrictID: u.DistrictID,
+				Name:       u.Name,
+				BnName:     u.BnName,
 				Lat:        lat,
 				Long:       long,
 			})
 		}
 	})
 
-	return loadErr
+	return loadUpazilaErr
 }
 
-func Districts() []types.District {
-	return data
+// Upazilas returns the loaded upazilas
+func Upazilas() []types.Upazila {
+	return upazilas
+}
+
+// LoadBoundaries reads the district boundary GeoJSON file once. Safe to call
+// multiple times.
+func LoadBoundaries(filepath string) error {
+	loadBoundaryOnce.Do(func() {
+		file, err := os.Open(filepath)
+		if err != nil {
+			loadBoundaryErr = err
+			return
+		}
+		defer file.Close()
+
+		boundaries, loadBoundaryErr = ParseBoundaries(file)
+	})
+
+	return loadBoundaryErr
+}
+
+// DistrictBoundaries returns the loaded district boundaries, or nil if they
+// weren't loaded
+func DistrictBoundaries() *Boundaries {
+	return boundaries
+}
+
+// LoadCountry reads the GeoJSON outline of Bangladesh once. Safe to call
+// multiple times.
+func LoadCountry(filepath string) error {
+	loadCountryOnce.Do(func() {
+		file, err := os.Open(filepath)
+		if err != nil {
+			loadCountryErr = err
+			return
+		}
+		defer file.Close()
+
+		country, loadCountryErr = ParseBoundaries(file)
+	})
+
+	return loadCountryErr
+}
+
+// CountryBoundary returns the loaded outline of Bangladesh, or nil if it
+// wasn't loaded
+func CountryBoundary() *Boundaries {
+	return country
+}
+
+func parseCoordinates(lat, long string) (float64, float64, error) {
+	la, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	lo, err := strconv.ParseFloat(long, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return la, lo, nil
 }
@@ -0,0 +1,236 @@
+package geodata
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// Index looks districts, divisions and upazilas up by ID or by name. Names
+// match ignoring case, punctuation and spaces, and include historic spellings
+// and Bangla names (directly or transliterated).
+type Index struct {
+	districts  []types.District
+	byID       map[string]types.District
+	byName     map[string]types.District // Keyed by every search key
+	keys       []searchKey
+	divisions  []types.Division
+	divisionBy map[string]types.Division // Keyed by ID and normalized names
+	upazilas   []types.Upazila
+	upazilaBy  map[string][]int // Keyed by ID and normalized names; names can repeat across districts
+	boundaries *Boundaries      // Optional; used to reverse geocode
+	country    *Boundaries      // Optional; decides whether a point is in Bangladesh without boundaries
+}
+
+// NewIndex indexes the given districts, divisions and upazilas. Upazilas are
+// linked to their parent district; those whose district isn't indexed are
+// left out.
+func NewIndex(districts []types.District, divisions []types.Division, upazilas []types.Upazila) *Index {
+	idx := &Index{
+		districts:  districts,
+		byID:       make(map[string]types.District, len(districts)),
+		byName:     make(map[string]types.District, 4*len(districts)),
+		divisions:  divisions,
+		divisionBy: make(map[string]types.Division, 4*len(divisions)),
+		upazilaBy:  make(map[string][]int, 4*len(upazilas)),
+	}
+
+	add := func(i int, key, name, matchType string) {
+		if key == "" {
+			return
+		}
+		// The first district to claim a key keeps it
+		if _, ok := idx.byName[key]; !ok {
+			idx.byName[key] = districts[i]
+		}
+		idx.keys = append(idx.keys, searchKey{key: key, name: name, matchType: matchType, district: i})
+	}
+
+	for i, d := range districts {
+		idx.byID[d.ID] = d
+		add(i, normalize(d.Name), d.Name, MatchExact)
+		add(i, normalizeBangla(d.BnName), d.BnName, MatchExact)
+		for _, alias := range aliases[d.Name] {
+			add(i, normalize(alias), alias, MatchAlias)
+		}
+		if d.BnName != "" {
+			latin := Transliterate(d.BnName)
+			add(i, normalize(latin), latin, MatchTransliteration)
+		}
+	}
+
+	// Division IDs are digits, so they can't clash with normalized names
+	for _, d := range divisions {
+		idx.divisionBy[d.ID] = d
+		idx.divisionBy[normalize(d.Name)] = d
+		idx.divisionBy[normalizeBangla(d.BnName)] = d
+		for _, alias := range aliases[d.Name] {
+			idx.divisionBy[normalize(alias)] = d
+		}
+	}
+
+	for _, u := range upazilas {
+		district, ok := idx.byID[u.DistrictID]
+		if !ok {
+			continue
+		}
+		u.DistrictName = district.Name
+
+		i := len(idx.upazilas)
+		idx.upazilas = append(idx.upazilas, u)
+		keys := []string{u.ID, normalize(u.Name), normalizeBangla(u.BnName)}
+		for _, alias := range aliases[u.Name] {
+			keys = append(keys, normalize(alias))
+		}
+		if u.BnName != "" {
+			keys = append(keys, normalize(Transliterate(u.BnName)))
+		}
+		for _, key := range keys {
+			if key != "" && !slices.Contains(idx.upazilaBy[key], i) {
+				idx.upazilaBy[key] = append(idx.upazilaBy[key], i)
+			}
+		}
+	}
+	return idx
+}
+
+// SetBoundaries makes reverse geocoding use district boundary polygons
+// instead of the nearest district center
+func (idx *Index) SetBoundaries(b *Boundaries) {
+	idx.boundaries = b
+}
+
+// Boundaries returns the district boundaries, or nil if none were set
+func (idx *Index) Boundaries() *Boundaries {
+	return idx.boundaries
+}
+
+// SetCountry sets the outline of Bangladesh. Without district boundaries,
+// reverse geocoding still finds the nearest district center but tests the
+// point against the outline to decide whether it is in Bangladesh.
+func (idx *Index) SetCountry(b *Boundaries) {
+	idx.country = b
+}
+
+// Country returns the outline of Bangladesh, or nil if none was set
+func (idx *Index) Country() *Boundaries {
+	return idx.country
+}
+
+// Districts returns every indexed district
+func (idx *Index) Districts() []types.District {
+	return idx.districts
+}
+
+// Divisions returns every indexed division
+func (idx *Index) Divisions() []types.Division {
+	return idx.divisions
+}
+
+// Division finds a division by ID or by English or Bangla name, ignoring
+// case, punctuation and spaces
+func (idx *Index) Division(identifier string) (types.Division, bool) {
+	if d, ok := idx.divisionBy[strings.TrimSpace(identifier)]; ok {
+		return d, true
+	}
+	for _, key := range queryKeys(identifier) {
+		if d, ok := idx.divisionBy[key]; ok {
+			return d, true
+		}
+	}
+	return types.Division{}, false
+}
+
+// Upazilas returns every indexed upazila, linked to its district
+func (idx *Index) Upazilas() []types.Upazila {
+	return idx.upazilas
+}
+
+// UpazilasIn returns the upazilas of a district, in dataset order
+func (idx *Index) UpazilasIn(districtID string) []types.Upazila {
+	districtID = strings.TrimSpace(districtID)
+
+	var upazilas []types.Upazila
+	for _, u := range idx.upazilas {
+		if u.DistrictID == districtID {
+			upazilas = append(upazilas, u)
+		}
+	}
+	return upazilas
+}
+
+// UpazilaDistricts returns the districts the upazila data covers, in dataset
+// order
+func (idx *Index) UpazilaDistricts() []types.District {
+	covered := make(map[string]bool)
+	for _, u := range idx.upazilas {
+		covered[u.DistrictID] = true
+	}
+
+	var districts []types.District
+	for _, d := range idx.districts {
+		if covered[d.ID] {
+			districts = append(districts, d)
+		}
+	}
+	return districts
+}
+
+// FindUpazilas finds upazilas by ID or by English or Bangla name. Several
+// districts can have an upazila of the same name, so every match is returned.
+func (idx *Index) FindUpazilas(identifier string) []types.Upazila {
+	positions, ok := idx.upazilaBy[strings.TrimSpace(identifier)]
+	if !ok {
+		for _, key := range queryKeys(identifier) {
+			if positions, ok = idx.upazilaBy[key]; ok {
+				break
+			}
+		}
+	}
+
+	upazilas := make([]types.Upazila, len(positions))
+	for i, p := range positions {
+		upazilas[i] = idx.upazilas[p]
+	}
+	return upazilas
+}
+
+// InDivision returns the districts of a division, in dataset order
+func (idx *Index) InDivision(divisionID string) []types.District {
+	divisionID = strings.TrimSpace(divisionID)
+
+	var districts []types.District
+	for _, d := range idx.districts {
+		if d.DivisionID == divisionID {
+			districts = append(districts, d)
+		}
+	}
+	return districts
+}
+
+// ByID finds a district by its ID
+func (idx *Index) ByID(id string) (types.District, bool) {
+	d, ok := idx.byID[strings.TrimSpace(id)]
+	return d, ok
+}
+
+// ByName finds a district by its English or Bangla name or a historic
+// spelling, ignoring case, punctuation and spaces. Typos don't match; use
+// Search or Suggest for those.
+func (idx *Index) ByName(name string) (types.District, bool) {
+	for _, key := range queryKeys(name) {
+		if d, ok := idx.byName[key]; ok {
+			return d, true
+		}
+	}
+	return types.District{}, false
+}
+
+// Lookup finds a district by ID or by English or Bangla name
+func (idx *Index) Lookup(identifier string) (types.District, bool) {
+	if d, ok := idx.ByID(identifier); ok {
+		return d, true
+	}
+	return idx.ByName(identifier)
+}
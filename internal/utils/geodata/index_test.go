@@ -0,0 +1,103 @@
+package geodata
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+func TestByNameIgnoresTypos(t *testing.T) {
+	idx := NewIndex(testDistricts, nil, nil)
+
+	if d, ok := idx.ByName("chittagong"); !ok || d.ID != "43" {
+		t.Errorf("expected Chattogram, got %+v", d)
+	}
+	if _, ok := idx.ByName("sylhte"); ok {
+		t.Error("expected a typo not to match by name")
+	}
+}
+
+func TestDivision(t *testing.T) {
+	idx := NewIndex(testDistricts, []types.Division{
+		{ID: "2", Name: "Chattogram", BnName: "চট্টগ্রাম"},
+		{ID: "7", Name: "Sylhet", BnName: "সিলেট"},
+		// The dataset spells য় precomposed; the query below is decomposed
+		{ID: "8", Name: "Mymensingh", BnName: "\u09AE\u09DF\u09AE\u09A8\u09B8\u09BF\u0982\u09B9"},
+	}, nil)
+
+	tests := []struct {
+		identifier string
+		expectedID string
+	}{
+		{"7", "7"},
+		{"sylhet", "7"},
+		{"Chittagong", "2"},
+		{"চট্টগ্রাম", "2"},
+		{"ময়মনসিংহ", "8"},
+		{"Atlantis", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			d, ok := idx.Division(tt.identifier)
+			if ok != (tt.expectedID != "") || d.ID != tt.expectedID {
+				t.Errorf("expected division %q, got %q (ok=%v)", tt.expectedID, d.ID, ok)
+			}
+		})
+	}
+}
+
+func TestFindUpazilas(t *testing.T) {
+	idx := NewIndex(testDistricts, nil, []types.Upazila{
+		{ID: "1", DistrictID: "54", Name: "Sylhet Sadar", BnName: "সিলেট সদর"},
+		{ID: "5", DistrictID: "54", Name: "Companiganj", BnName: "কোম্পানীগঞ্জ"},
+		{ID: "9", DistrictID: "99", Name: "Nowhere"},
+		{ID: "14", DistrictID: "43", Name: "Companiganj", BnName: "কোম্পানীগঞ্জ"},
+	})
+
+	tests := []struct {
+		name        string
+		identifier  string
+		expectedIDs []string
+	}{
+		{"by ID", "1", []string{"1"}},
+		{"by name", "sylhet sadar", []string{"1"}},
+		{"by Bangla name", "সিলেট সদর", []string{"1"}},
+		{"by transliteration", "Silet Sadar", []string{"1"}},
+		{"name in several districts", "Companiganj", []string{"5", "14"}},
+		{"district not indexed", "Nowhere", nil},
+		{"no match", "Atlantis", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, u := range idx.FindUpazilas(tt.identifier) {
+				ids = append(ids, u.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("expected %v, got %v", tt.expectedIDs, ids)
+			}
+		})
+	}
+
+	t.Run("linked to the parent district", func(t *testing.T) {
+		upazilas := idx.UpazilasIn("54")
+		if len(upazilas) != 2 || upazilas[0].DistrictName != "Sylhet" {
+			t.Errorf("expected Sylhet's 2 upazilas, got %+v", upazilas)
+		}
+	})
+
+	t.Run("covered districts", func(t *testing.T) {
+		var ids []string
+		for _, d := range idx.UpazilaDistricts() {
+			ids = append(ids, d.ID)
+		}
+		sort.Strings(ids)
+		if !reflect.DeepEqual(ids, []string{"43", "54"}) {
+			t.Errorf("expected districts 43 and 54, got %v", ids)
+		}
+	})
+}
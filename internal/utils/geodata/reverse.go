@@ -0,0 +1,124 @@
+package geodata
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shuv1824/recommender/internal/types"
+	"github.com/shuv1824/recommender/internal/utils/geo"
+)
+
+// How a point was located
+const (
+	MethodBoundary      = "boundary"       // Point-in-polygon against district boundaries
+	MethodNearestCenter = "nearest_center" // Nearest district center, when no boundaries are loaded
+)
+
+// Bangladesh's bounding box, with a little margin for the coast and islands
+const (
+	minLat  = 20.5
+	maxLat  = 26.7
+	minLong = 88.0
+	maxLong = 92.7
+)
+
+// maxDistrictDistanceKm is how far a point inside the bounding box may be from
+// the nearest district center and still count as in Bangladesh. It is wide
+// enough for Saint Martin's Island and the Sundarbans, whose districts are
+// centered far inland.
+const maxDistrictDistanceKm = 100.0
+
+// ReverseGeocode finds the district a point is in and, when the district has
+// upazila data, the nearest upazila in it. With boundaries set the district
+// is the one whose boundary contains the point; otherwise it is the nearest
+// district center, which can be wrong near borders, and the country outline,
+// when set, decides whether the point is in Bangladesh. Points outside
+// Bangladesh get the nearest district anyway, with a warning. The district is
+// nil only when the index is empty.
+func (idx *Index) ReverseGeocode(lat, long float64) types.ReverseGeocode {
+	result := types.ReverseGeocode{Lat: lat, Long: long, Method: MethodNearestCenter}
+	point := types.Location{Lat: lat, Long: long}
+
+	if len(idx.districts) == 0 {
+		result.Warning = "no district data to locate the point with"
+		return result
+	}
+
+	var district types.District
+	found := false
+	if idx.boundaries != nil && idx.boundaries.Len() > 0 {
+		result.Method = MethodBoundary
+
+		// Features keyed by name resolve like a district name
+		id, km := idx.boundaries.Nearest(lat, long)
+		district, found = idx.Lookup(id)
+		result.InBangladesh = found && km == 0
+		if found && km > 0 {
+			result.BoundaryDistanceKm = roundKm(km)
+			result.Warning = fmt.Sprintf("(%.4f, %.4f) is outside the supported region; the nearest district, %s, is %.1f km away",
+				lat, long, district.Name, km)
+		}
+	}
+
+	// Without boundaries, or with none for the nearest district's ID
+	if !found {
+		result.Method = MethodNearestCenter
+
+		nearestKm := math.Inf(1)
+		for _, d := range idx.districts {
+			if km := geo.DistanceKm(point, types.Location{Lat: d.Lat, Long: d.Long}); km < nearestKm {
+				district, nearestKm = d, km
+			}
+		}
+		result.InBangladesh = inBoundingBox(lat, long) && nearestKm <= maxDistrictDistanceKm
+		if idx.country != nil && idx.country.Len() > 0 {
+			_, km := idx.country.Nearest(lat, long)
+			result.InBangladesh = km == 0
+			result.BoundaryDistanceKm = roundKm(km)
+		}
+		switch {
+		case result.InBangladesh:
+		case result.BoundaryDistanceKm > 0:
+			result.Warning = fmt.Sprintf("(%.4f, %.4f) is outside Bangladesh, %.1f km from the border; the nearest district, %s, is %.0f km away",
+				lat, long, result.BoundaryDistanceKm, district.Name, nearestKm)
+		default:
+			result.Warning = fmt.Sprintf("(%.4f, %.4f) appears to be outside Bangladesh; the nearest district, %s, is %.0f km away",
+				lat, long, district.Name, nearestKm)
+		}
+	}
+
+	result.District = &types.NearbyPlace{
+		ID:         district.ID,
+		Name:       district.Name,
+		BnName:     district.BnName,
+		DistanceKm: roundKm(geo.DistanceKm(point, types.Location{Lat: district.Lat, Long: district.Long})),
+	}
+	if !result.InBangladesh {
+		return result
+	}
+
+	nearestKm := math.Inf(1)
+	for _, u := range idx.UpazilasIn(district.ID) {
+		if km := geo.DistanceKm(point, types.Location{Lat: u.Lat, Long: u.Long}); km < nearestKm {
+			nearestKm = km
+			result.Upazila = &types.NearbyPlace{
+				ID:     u.ID,
+				Name:   u.Name,
+				BnName: u.BnName,
+			}
+		}
+	}
+	if result.Upazila != nil {
+		result.Upazila.DistanceKm = roundKm(nearestKm)
+	}
+	return result
+}
+
+func inBoundingBox(lat, long float64) bool {
+	return lat >= minLat && lat <= maxLat && long >= minLong && long <= maxLong
+}
+
+// roundKm rounds a distance to 100 m
+func roundKm(km float64) float64 {
+	return math.Round(km*10) / 10
+}
@@ -0,0 +1,177 @@
+package geodata
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+func TestReverseGeocode(t *testing.T) {
+	idx := NewIndex([]types.District{
+		{ID: "1", Name: "Dhaka", BnName: "ঢাকা", Lat: 23.7115, Long: 90.4111},
+		{ID: "45", Name: "Cox's Bazar", BnName: "কক্স বাজার", Lat: 21.4272, Long: 92.0058},
+		{ID: "54", Name: "Sylhet", BnName: "সিলেট", Lat: 24.8898, Long: 91.8698},
+	}, nil, []types.Upazila{
+		{ID: "1", DistrictID: "54", Name: "Sylhet Sadar", BnName: "সিলেট সদর", Lat: 24.8949, Long: 91.8687},
+		{ID: "5", DistrictID: "54", Name: "Companiganj", BnName: "কোম্পানীগঞ্জ", Lat: 25.0700, Long: 91.7500},
+		{ID: "27", DistrictID: "45", Name: "Teknaf", BnName: "টেকনাফ", Lat: 20.8624, Long: 92.3058},
+	})
+
+	tests := []struct {
+		name         string
+		lat, long    float64
+		district     string
+		upazila      string
+		inBangladesh bool
+	}{
+		{"district without upazila data", 23.8103, 90.4125, "Dhaka", "", true},
+		{"nearest upazila", 25.05, 91.76, "Sylhet", "Companiganj", true},
+		{"offshore island", 20.6270, 92.3225, "Cox's Bazar", "Teknaf", true},
+		{"neighbouring country", 22.5726, 88.3639, "Dhaka", "", false},
+		{"far away", 51.5072, -0.1276, "Sylhet", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := idx.ReverseGeocode(tt.lat, tt.long)
+
+			if result.District == nil || result.District.Name != tt.district {
+				t.Fatalf("expected district %s, got %+v", tt.district, result.District)
+			}
+			upazila := ""
+			if result.Upazila != nil {
+				upazila = result.Upazila.Name
+			}
+			if upazila != tt.upazila {
+				t.Errorf("expected upazila %q, got %q", tt.upazila, upazila)
+			}
+			if result.InBangladesh != tt.inBangladesh {
+				t.Errorf("expected in_bangladesh %v, got %v", tt.inBangladesh, result.InBangladesh)
+			}
+			if (result.Warning == "") != tt.inBangladesh {
+				t.Errorf("expected a warning only outside Bangladesh, got %q", result.Warning)
+			}
+		})
+	}
+
+	t.Run("boundaries", func(t *testing.T) {
+		b, err := ParseBoundaries(strings.NewReader(testBoundaries))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		idx := NewIndex([]types.District{
+			{ID: "1", Name: "West", Lat: 23.5, Long: 90.1},
+			{ID: "2", Name: "East", Lat: 23.5, Long: 91.2}, // Nearer the border than West's center
+		}, nil, nil)
+		idx.SetBoundaries(b)
+
+		result := idx.ReverseGeocode(23.5, 90.95)
+		if result.Method != MethodBoundary || result.District.Name != "West" || !result.InBangladesh {
+			t.Errorf("expected the containing district West, got %+v (%s)", result.District, result.Method)
+		}
+
+		result = idx.ReverseGeocode(23.5, 89.9)
+		if result.InBangladesh || result.District.Name != "West" || result.BoundaryDistanceKm != 10.2 || result.Warning == "" {
+			t.Errorf("expected a warning 10.2 km outside West, got %+v", result)
+		}
+	})
+
+	t.Run("boundaries keyed by name", func(t *testing.T) {
+		// geoBoundaries features have a shapeName but no district id
+		b, err := ParseBoundaries(strings.NewReader(`{"features": [{"properties": {"shapeName": "Chittagong"}, "geometry": {"type": "Polygon",
+			"coordinates": [[[91, 22], [92, 22], [92, 23], [91, 23], [91, 22]]]}}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		idx := NewIndex([]types.District{{ID: "43", Name: "Chattogram", Lat: 22.3569, Long: 91.7832}}, nil, nil)
+		idx.SetBoundaries(b)
+
+		if result := idx.ReverseGeocode(22.5, 91.5); result.Method != MethodBoundary || result.District.ID != "43" || !result.InBangladesh {
+			t.Errorf("expected the historic name to resolve to Chattogram, got %+v (%s)", result.District, result.Method)
+		}
+	})
+
+	t.Run("country outline", func(t *testing.T) {
+		file, err := os.Open("../../../data/bangladesh.geojson")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer file.Close()
+		country, err := ParseBoundaries(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		idx.SetCountry(country)
+		defer idx.SetCountry(nil)
+
+		tests := []struct {
+			name         string
+			lat, long    float64
+			inBangladesh bool
+		}{
+			{"Dhaka", 23.8103, 90.4125, true},
+			{"Saint Martin's Island", 20.6270, 92.3225, true},
+			{"Dahagram enclave", 26.3100, 88.9500, true},
+			{"West Bengal, near Benapole", 23.0437, 88.8206, false},
+			{"Kolkata", 22.5726, 88.3639, false},
+			{"Bay of Bengal", 21.0, 90.5, false},
+		}
+		for _, tt := range tests {
+			result := idx.ReverseGeocode(tt.lat, tt.long)
+			if result.InBangladesh != tt.inBangladesh || (result.BoundaryDistanceKm > 0) == tt.inBangladesh {
+				t.Errorf("%s: expected in_bangladesh %v, got %+v", tt.name, tt.inBangladesh, result)
+			}
+		}
+	})
+
+	t.Run("no districts", func(t *testing.T) {
+		if result := NewIndex(nil, nil, nil).ReverseGeocode(23.8, 90.4); result.District != nil {
+			t.Errorf("expected no district, got %+v", result.District)
+		}
+	})
+}
+
+func TestReverseGeocodeDistrictBoundaries(t *testing.T) {
+	file, err := os.Open("../../../data/districts.geojson")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("data/districts.geojson is not bundled; see Data Files in the README")
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+	b, err := ParseBoundaries(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Load("../../../data/districts.json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	idx := NewIndex(Districts(), Divisions(), nil)
+	idx.SetBoundaries(b)
+
+	// Points near district borders, where the nearest district center is wrong
+	tests := []struct {
+		name      string
+		lat, long float64
+		district  string
+	}{
+		{"Sreemangal", 24.3065, 91.7296, "Maulvibazar"},
+		{"Lawachara", 24.3200, 91.7900, "Maulvibazar"},
+		{"Satchari", 24.1200, 91.4500, "Habiganj"},
+		{"Habiganj town", 24.3749, 91.4155, "Habiganj"},
+		{"Teknaf", 20.8624, 92.3058, "Cox's Bazar"},
+		{"Shah Porir Dwip", 20.7800, 92.3300, "Cox's Bazar"},
+	}
+	for _, tt := range tests {
+		result := idx.ReverseGeocode(tt.lat, tt.long)
+		if result.Method != MethodBoundary || result.District == nil || result.District.Name != tt.district || !result.InBangladesh {
+			t.Errorf("%s: expected %s by boundary, got %+v", tt.name, tt.district, result)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package geodata
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// How a search query matched a district
+const (
+	MatchExact           = "exact"           // English or Bangla name
+	MatchAlias           = "alias"           // Historic or alternate spelling
+	MatchTransliteration = "transliteration" // Latin transliteration of the Bangla name
+	MatchPrefix          = "prefix"
+	MatchFuzzy           = "fuzzy"
+)
+
+// aliases are historic and alternate spellings of district and upazila
+// names, keyed by the name in the dataset
+var aliases = map[string][]string{
+	"Dhaka":        {"Dacca"},
+	"Bogura":       {"Bogra"},
+	"Nawabganj":    {"Chapai Nawabganj", "Chapainawabganj", "Nawabgonj"},
+	"Sirajgonj":    {"Sirajganj"},
+	"Barishal":     {"Barisal"},
+	"Jhalokati":    {"Jhalakathi", "Jhalokathi", "Jhalakati"},
+	"Chattogram":   {"Chittagong", "Chattagram"},
+	"Cumilla":      {"Comilla"},
+	"Maulvibazar":  {"Moulvibazar"},
+	"Jashore":      {"Jessore"},
+	"Netrokona":    {"Netrakona"},
+	"Khagrachari":  {"Khagrachhari"},
+	"Lakshmipur":   {"Laxmipur"},
+	"Jhenaidah":    {"Jhenida"},
+	"Joypurhat":    {"Jaipurhat"},
+	"Brahmanbaria": {"B. Baria"},
+	"Sylhet":       {"Srihatta"},
+	"Sreemangal":   {"Srimangal"},
+	"Maheshkhali":  {"Moheshkhali"},
+}
+
+// Match is a district found by a search
+type Match struct {
+	District  types.District
+	Matched   string // The name, alias or transliteration that matched
+	MatchType string
+	Distance  int // Edit distance for fuzzy matches
+}
+
+// searchKey is one normalized spelling of a district's name
+type searchKey struct {
+	key       string
+	name      string
+	matchType string
+	district  int // Index into Index.districts
+}
+
+// Search finds districts by name, ignoring case, punctuation and spaces, and
+// matching historic spellings and Bangla names (directly or transliterated).
+// Exact matches come first, then prefix matches, then names within a few
+// typos. At most limit matches are returned; limit <= 0 means no limit.
+func (idx *Index) Search(query string, limit int) []Match {
+	keys := queryKeys(query)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	best := make(map[int]Match)
+	consider := func(i int, m Match) {
+		prev, ok := best[i]
+		if !ok || matchRank(m) < matchRank(prev) {
+			best[i] = m
+		}
+	}
+
+	for _, q := range keys {
+		for _, k := range idx.keys {
+			m := Match{District: idx.districts[k.district], Matched: k.name}
+			switch {
+			case k.key == q:
+				m.MatchType = k.matchType
+			case len(q) >= 2 && strings.HasPrefix(k.key, q):
+				m.MatchType = MatchPrefix
+			default:
+				d := editDistance(q, k.key)
+				if d > maxTypos(q) {
+					continue
+				}
+				m.MatchType = MatchFuzzy
+				m.Distance = d
+			}
+			consider(k.district, m)
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matchRank(matches[i]) != matchRank(matches[j]) {
+			return matchRank(matches[i]) < matchRank(matches[j])
+		}
+		return matches[i].District.Name < matches[j].District.Name
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Suggest lists the names of districts within a few typos of the query
+func (idx *Index) Suggest(query string, limit int) []string {
+	var names []string
+	for _, m := range idx.Search(query, limit) {
+		names = append(names, m.District.Name)
+	}
+	return names
+}
+
+// matchRank orders matches: exact-style matches, then prefixes, then typos
+func matchRank(m Match) int {
+	switch m.MatchType {
+	case MatchExact, MatchAlias, MatchTransliteration:
+		return 0
+	case MatchPrefix:
+		return 1
+	default:
+		return 2 + m.Distance
+	}
+}
+
+// maxTypos is how many edits a query of this length may be from a name
+func maxTypos(q string) int {
+	n := len([]rune(q))
+	switch {
+	case n < 4:
+		return 0
+	case n < 7:
+		return 1
+	case n < 10:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// normalize case-folds a Latin name and strips everything but letters and
+// digits, so "Cox's bazar" and "Coxsbazar" are the same key
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// bengaliComposed maps consonants that take a nukta to their precomposed
+// letters, so ড় typed either way is the same key
+var bengaliComposed = map[rune]rune{'ড': '\u09DC', 'ঢ': '\u09DD', 'য': '\u09DF'}
+
+// normalizeBangla strips spaces and punctuation from a Bangla name, keeping
+// Bengali letters and signs with nukta forms precomposed
+func normalizeBangla(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !isBengali(r) {
+			continue
+		}
+		if c, ok := bengaliComposed[r]; ok && i+1 < len(runes) && runes[i+1] == bengaliNukta {
+			r = c
+			i++
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// queryKeys returns the keys a query is matched by: its Bangla form and
+// transliteration for Bangla queries, its normalized form otherwise
+func queryKeys(query string) []string {
+	if containsBengali(query) {
+		var keys []string
+		if bn := normalizeBangla(query); bn != "" {
+			keys = append(keys, bn)
+		}
+		if latin := normalize(Transliterate(query)); latin != "" {
+			keys = append(keys, latin)
+		}
+		return keys
+	}
+	if key := normalize(query); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+func isBengali(r rune) bool {
+	return r >= 0x0980 && r <= 0x09FF
+}
+
+func containsBengali(s string) bool {
+	for _, r := range s {
+		if isBengali(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance is the optimal string alignment distance between two
+// strings: insertions, deletions, substitutions and swaps of adjacent
+// characters each count as one edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,108 @@
+package geodata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+var testDistricts = []types.District{
+	{ID: "1", Name: "Dhaka", BnName: "ঢাকা"},
+	{ID: "11", Name: "Narayanganj", BnName: "নারায়াণগঞ্জ"},
+	{ID: "25", Name: "Nawabganj", BnName: "নবাবগঞ্জ"},
+	{ID: "35", Name: "Barishal", BnName: "বরিশাল"},
+	{ID: "43", Name: "Chattogram", BnName: "চট্টগ্রাম"},
+	{ID: "45", Name: "Cox's Bazar", BnName: "কক্স বাজার"},
+	{ID: "54", Name: "Sylhet", BnName: "সিলেট"},
+}
+
+func TestSearch(t *testing.T) {
+	idx := NewIndex(testDistricts, nil, nil)
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  []string
+		matchType string
+	}{
+		{"exact name", "Dhaka", []string{"Dhaka"}, MatchExact},
+		{"case and punctuation", "COXSBAZAR", []string{"Cox's Bazar"}, MatchExact},
+		{"historic spelling", "Chittagong", []string{"Chattogram"}, MatchAlias},
+		{"Bangla name", "কক্সবাজার", []string{"Cox's Bazar"}, MatchExact},
+		{"transliterated Bangla", "silet", []string{"Sylhet"}, MatchTransliteration},
+		{"prefix", "dhak", []string{"Dhaka"}, MatchPrefix},
+		{"one typo", "borishal", []string{"Barishal"}, MatchFuzzy},
+		{"swapped letters", "sylhte", []string{"Sylhet"}, MatchFuzzy},
+		{"closest typo first", "Naraynganj", []string{"Narayanganj", "Nawabganj"}, MatchFuzzy},
+		{"too short for typos", "dka", nil, ""},
+		{"no match", "London", nil, ""},
+		{"empty", " '", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := idx.Search(tt.query, 0)
+
+			var names []string
+			for _, m := range matches {
+				names = append(names, m.District.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, names)
+			}
+			if len(matches) > 0 && matches[0].MatchType != tt.matchType {
+				t.Errorf("expected a %s match, got %s", tt.matchType, matches[0].MatchType)
+			}
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		if matches := idx.Search("Naraynganj", 1); len(matches) != 1 {
+			t.Errorf("expected 1 match, got %d", len(matches))
+		}
+	})
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ঢাকা", "dhaka"},
+		{"রংপুর", "rangpur"},
+		{"সিলেট", "silet"},
+		{"কুমিল্লা", "kumilla"},
+		{"বগুড়া", "bagura"},
+		{"কক্স বাজার", "kaks bajar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := Transliterate(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"dhaka", "dhaka", 0},
+		{"dhaka", "dhak", 1},
+		{"barisal", "borishal", 2},
+		{"sylhet", "sylhte", 1},
+		{"", "feni", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package geodata
+
+import "strings"
+
+// Bengali script to Latin, roughly as district names are romanized. This is
+// for matching, not display: it doesn't try to be a standard romanization.
+var (
+	bengaliVowels = map[rune]string{
+		'অ': "a", 'আ': "a", 'ই': "i", 'ঈ': "i", 'উ': "u", 'ঊ': "u",
+		'ঋ': "ri", 'এ': "e", 'ঐ': "oi", 'ও': "o", 'ঔ': "ou",
+	}
+	bengaliVowelSigns = map[rune]string{
+		'া': "a", 'ি': "i", 'ী': "i", 'ু': "u", 'ূ': "u",
+		'ৃ': "ri", 'ে': "e", 'ৈ': "oi", 'ো': "o", 'ৌ': "ou",
+	}
+	bengaliConsonants = map[rune]string{
+		'ক': "k", 'খ': "kh", 'গ': "g", 'ঘ': "gh", 'ঙ': "ng",
+		'চ': "ch", 'ছ': "chh", 'জ': "j", 'ঝ': "jh", 'ঞ': "n",
+		'ট': "t", 'ঠ': "th", 'ড': "d", 'ঢ': "dh", 'ণ': "n",
+		'ত': "t", 'থ': "th", 'দ': "d", 'ধ': "dh", 'ন': "n",
+		'প': "p", 'ফ': "ph", 'ব': "b", 'ভ': "bh", 'ম': "m",
+		'য': "j", 'র': "r", 'ল': "l", 'শ': "sh", 'ষ': "sh",
+		'স': "s", 'হ': "h", '\u09DC': "r", '\u09DD': "rh", '\u09DF': "y", // ড়, ঢ়, য়
+	}
+	// bengaliNuktaForms romanizes a consonant followed by a nukta sign, the
+	// decomposed spelling of ড়, ঢ় and য়
+	bengaliNuktaForms = map[rune]string{'ড': "r", 'ঢ': "rh", 'য': "y"}
+	bengaliSigns      = map[rune]string{
+		'ং': "ng", 'ঃ': "h", 'ৎ': "t", 'ঁ': "",
+	}
+)
+
+const (
+	bengaliVirama = '্'
+	bengaliNukta  = '়'
+)
+
+// Transliterate romanizes Bengali script. Consonants carry an inherent "a"
+// unless a vowel sign or virama follows, and the inherent vowel is dropped at
+// the end of a word, as in spoken Bangla (ঢাকা → dhaka, রংপুর → rangpur).
+// Other characters are kept as they are.
+func Transliterate(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	// pending is true while the last consonant may still take an inherent "a"
+	pending := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if sign, ok := bengaliVowelSigns[r]; ok {
+			b.WriteString(sign)
+			pending = false
+			continue
+		}
+		if r == bengaliVirama {
+			pending = false
+			continue
+		}
+
+		if pending {
+			if continuesWord(r) {
+				b.WriteString("a")
+			}
+			pending = false
+		}
+
+		if c, ok := bengaliConsonants[r]; ok {
+			if i+1 < len(runes) && runes[i+1] == bengaliNukta {
+				if n, ok := bengaliNuktaForms[r]; ok {
+					c = n
+				}
+				i++
+			}
+			b.WriteString(c)
+			pending = true
+			continue
+		}
+		if v, ok := bengaliVowels[r]; ok {
+			b.WriteString(v)
+			continue
+		}
+		if sign, ok := bengaliSigns[r]; ok {
+			b.WriteString(sign)
+			continue
+		}
+		if r == bengaliNukta {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// continuesWord reports whether r continues a Bengali word, so a pending
+// inherent vowel is spoken before it
+func continuesWord(r rune) bool {
+	if _, ok := bengaliConsonants[r]; ok {
+		return true
+	}
+	if _, ok := bengaliVowels[r]; ok {
+		return true
+	}
+	_, ok := bengaliSigns[r]
+	return ok && r != 'ঁ'
+}
@@ -0,0 +1,147 @@
+package pollutant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shuv1824/recommender/internal/types"
+)
+
+// Pollutant keys accepted by the API
+const (
+	PM25 = "pm25"
+	PM10 = "pm10"
+	NO2  = "no2"
+	O3   = "o3"
+	SO2  = "so2"
+	CO   = "co"
+)
+
+// Keys lists every supported pollutant in display order
+var Keys = []string{PM25, PM10, NO2, O3, SO2, CO}
+
+// Default is the pollutant set used when a request doesn't specify one
+var Default = []string{PM25}
+
+// referenceLevels are the WHO 2021 short-term guideline concentrations (µg/m³)
+// used to put pollutants with very different magnitudes on a common scale.
+var referenceLevels = map[string]float64{
+	PM25: 15,
+	PM10: 45,
+	NO2:  25,
+	O3:   100,
+	SO2:  40,
+	CO:   4000,
+}
+
+var labels = map[string]string{
+	PM25: "PM2.5",
+	PM10: "PM10",
+	NO2:  "NO2",
+	O3:   "O3",
+	SO2:  "SO2",
+	CO:   "CO",
+}
+
+// Label returns the human-readable name of a pollutant key
+func Label(key string) string {
+	return labels[key]
+}
+
+// Labels returns human-readable names for the given pollutant keys
+func Labels(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, labels[k])
+	}
+	return out
+}
+
+// Normalize lower-cases, de-duplicates and validates pollutant keys.
+// An empty list yields Default.
+func Normalize(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return Default, nil
+	}
+
+	seen := make(map[string]bool, len(keys))
+	normalized := make([]string, 0, len(keys))
+	for _, k := range keys {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" || seen[k] {
+			continue
+		}
+		if _, ok := referenceLevels[k]; !ok {
+			return nil, fmt.Errorf("unknown pollutant %q, supported: %s", k, strings.Join(Keys, ", "))
+		}
+		seen[k] = true
+		normalized = append(normalized, k)
+	}
+
+	if len(normalized) == 0 {
+		return Default, nil
+	}
+
+	return normalized, nil
+}
+
+// Parse splits a comma-separated pollutant list and normalizes it
+func Parse(csv string) ([]string, error) {
+	if csv == "" {
+		return Default, nil
+	}
+	return Normalize(strings.Split(csv, ","))
+}
+
+// Value returns the concentration of a single pollutant
+func Value(p types.Pollutants, key string) float64 {
+	switch key {
+	case PM25:
+		return p.PM25
+	case PM10:
+		return p.PM10
+	case NO2:
+		return p.NO2
+	case O3:
+		return p.O3
+	case SO2:
+		return p.SO2
+	case CO:
+		return p.CO
+	default:
+		return 0
+	}
+}
+
+// ReferenceLevel returns the WHO 2021 guideline concentration a pollutant is
+// normalized against
+func ReferenceLevel(key string) float64 {
+	return referenceLevels[key]
+}
+
+// Score combines the given pollutants into a single number by averaging each
+// concentration as a multiple of its reference level. Lower is cleaner.
+func Score(p types.Pollutants, keys []string) float64 {
+	if len(keys) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, k := range keys {
+		if ref := referenceLevels[k]; ref > 0 {
+			sum += Value(p, k) / ref
+		}
+	}
+
+	return sum / float64(len(keys))
+}
+
+// Differences returns current minus destination for each pollutant key.
+// Positive values mean the destination is cleaner for that pollutant.
+func Differences(current, destination types.Pollutants, keys []string) map[string]float64 {
+	diffs := make(map[string]float64, len(keys))
+	for _, k := range keys {
+		diffs[k] = Value(current, k) - Value(destination, k)
+	}
+	return diffs
+}
@@ -0,0 +1,41 @@
+package uvindex
+
+// UV index categories as defined by the WHO Global Solar UV Index
+const (
+	Low      = "low"
+	Moderate = "moderate"
+	High     = "high"
+	VeryHigh = "very high"
+	Extreme  = "extreme"
+)
+
+// Category returns the WHO exposure category for a UV index value
+func Category(uv float64) string {
+	switch {
+	case uv < 3:
+		return Low
+	case uv < 6:
+		return Moderate
+	case uv < 8:
+		return High
+	case uv < 11:
+		return VeryHigh
+	default:
+		return Extreme
+	}
+}
+
+// Advice returns sun-protection guidance for a UV index value.
+// Returns an empty string when the UV index is below the high category.
+func Advice(uv float64) string {
+	switch Category(uv) {
+	case High:
+		return "Midday UV is high: wear sunscreen, a hat and sunglasses, and seek shade around noon."
+	case VeryHigh:
+		return "Midday UV is very high: use SPF 30+ sunscreen, cover up and avoid direct sun between 11AM and 3PM."
+	case Extreme:
+		return "Midday UV is extreme: unprotected skin can burn in minutes, so stay indoors or in shade around midday."
+	default:
+		return ""
+	}
+}